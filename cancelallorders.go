@@ -25,13 +25,18 @@ type CancelAllOrdersResponse struct {
 //
 // Method: private/cancel-all-orders
 func (c *client) CancelAllOrders(ctx context.Context, instrumentName string) error {
+	return c.cancelAllOrders(ctx, methodCancelAllOrders, instrumentName)
+}
+
+// cancelAllOrders cancels all orders for an instrument via the specified method (spot or margin).
+func (c *client) cancelAllOrders(ctx context.Context, method string, instrumentName string) error {
 	if instrumentName == "" {
 		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
 	}
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -41,7 +46,7 @@ func (c *client) CancelAllOrders(ctx context.Context, instrumentName string) err
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodCancelAllOrders,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -51,7 +56,7 @@ func (c *client) CancelAllOrders(ctx context.Context, instrumentName string) err
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodCancelAllOrders,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -59,7 +64,7 @@ func (c *client) CancelAllOrders(ctx context.Context, instrumentName string) err
 	}
 
 	var cancelAllOrdersResponse CancelAllOrdersResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCancelAllOrders, &cancelAllOrdersResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &cancelAllOrdersResponse)
 	if err != nil {
 		return fmt.Errorf("failed to execute post request: %w", err)
 	}
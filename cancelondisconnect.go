@@ -0,0 +1,142 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/websocket"
+)
+
+const (
+	methodSetCancelOnDisconnect = "private/set-cancel-on-disconnect"
+	methodGetCancelOnDisconnect = "private/get-cancel-on-disconnect"
+
+	CancelOnDisconnectScopeAccount    CancelOnDisconnectScope = "ACCOUNT"
+	CancelOnDisconnectScopeConnection CancelOnDisconnectScope = "CONNECTION"
+)
+
+type (
+	// CancelOnDisconnectScope is the scope of orders cancelled when the websocket session is disconnected.
+	//
+	// ACCOUNT cancels all open orders of the account, CONNECTION only cancels orders created through the session.
+	CancelOnDisconnectScope string
+
+	// CancelOnDisconnectResult is the result returned from the private/set-cancel-on-disconnect
+	// and private/get-cancel-on-disconnect APIs.
+	CancelOnDisconnectResult struct {
+		// Scope is the scope of cancel-on-disconnect applied to the session.
+		Scope CancelOnDisconnectScope `json:"scope"`
+	}
+)
+
+// EnableCancelOnDisconnect enables cancel-on-disconnect for the session, so that open orders are cancelled
+// by the Exchange if the connection is lost.
+//
+// The scope is re-applied each time the connection is re-established.
+//
+// Method: private/set-cancel-on-disconnect
+func (u *userWebsocket) EnableCancelOnDisconnect(ctx context.Context, scope CancelOnDisconnectScope) error {
+	if scope != CancelOnDisconnectScopeAccount && scope != CancelOnDisconnectScopeConnection {
+		return errors.InvalidParameterError{Parameter: "scope", Reason: "must be ACCOUNT or CONNECTION"}
+	}
+
+	// the scope is stored before it is sent, so that it is re-applied if the connection is re-established meanwhile.
+	u.mu.Lock()
+	previous := u.cancelOnDisconnect
+	u.cancelOnDisconnect = scope
+	u.mu.Unlock()
+
+	if err := u.setCancelOnDisconnect(ctx, u.conn, scope); err != nil {
+		u.mu.Lock()
+		if u.cancelOnDisconnect == scope {
+			u.cancelOnDisconnect = previous
+		}
+		u.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// DisableCancelOnDisconnect disables cancel-on-disconnect for the session.
+//
+// The Exchange binds the scope to the session and does not allow it to be removed, so the session is replaced:
+// a new connection is established, authenticated and has its subscriptions replayed without the scope,
+// before the previous connection is closed. As the previous session is disconnected, the Exchange cancels
+// the orders within its scope one last time. The scope is kept if a new connection cannot be established.
+//
+// Nothing is done if cancel-on-disconnect is not enabled.
+func (u *userWebsocket) DisableCancelOnDisconnect(ctx context.Context) error {
+	u.mu.Lock()
+	previous := u.cancelOnDisconnect
+	u.cancelOnDisconnect = ""
+	u.mu.Unlock()
+
+	if previous == "" {
+		return nil
+	}
+
+	if err := u.conn.Reconnect(ctx); err != nil {
+		u.mu.Lock()
+		if u.cancelOnDisconnect == "" {
+			u.cancelOnDisconnect = previous
+		}
+		u.mu.Unlock()
+
+		return fmt.Errorf("failed to reconnect: %w", err)
+	}
+
+	return nil
+}
+
+// GetCancelOnDisconnect fetches the cancel-on-disconnect scope applied to the session.
+//
+// Method: private/get-cancel-on-disconnect
+func (u *userWebsocket) GetCancelOnDisconnect(ctx context.Context) (CancelOnDisconnectScope, error) {
+	res, err := u.conn.Send(ctx, api.Request{
+		ID:     u.idGenerator.Generate(),
+		Method: methodGetCancelOnDisconnect,
+		Nonce:  u.nonce(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get cancel on disconnect: %w", err)
+	}
+
+	var result CancelOnDisconnectResult
+	if err := json.Unmarshal(res.Result, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+
+	return result.Scope, nil
+}
+
+// restoreCancelOnDisconnect re-applies the enabled cancel-on-disconnect scope (if any) to a newly established session.
+func (u *userWebsocket) restoreCancelOnDisconnect(ctx context.Context, conn *websocket.Conn) error {
+	u.mu.Lock()
+	scope := u.cancelOnDisconnect
+	u.mu.Unlock()
+
+	if scope == "" {
+		return nil
+	}
+
+	return u.setCancelOnDisconnect(ctx, conn, scope)
+}
+
+func (u *userWebsocket) setCancelOnDisconnect(ctx context.Context, conn *websocket.Conn, scope CancelOnDisconnectScope) error {
+	_, err := conn.Send(ctx, api.Request{
+		ID:     u.idGenerator.Generate(),
+		Method: methodSetCancelOnDisconnect,
+		Nonce:  u.nonce(),
+		Params: map[string]interface{}{"scope": scope},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cancel on disconnect: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,393 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+// newCancelOnDisconnectWebsocket opens a user websocket to url, reconnects are disabled unless opts override it.
+func newCancelOnDisconnectWebsocket(t *testing.T, ctx context.Context, url string, clock clockwork.Clock, opts ...cdcexchange.ClientOption) cdcexchange.UserWebsocket {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+	)
+
+	client, err := cdcexchange.New("some api key", "some secret key", append([]cdcexchange.ClientOption{
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithUserWebsocketURL(url),
+		cdcexchange.WithWebsocketHeartbeatTimeout(0),
+		cdcexchange.WithWebsocketReconnectDisabled(),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(int64(1234)).AnyTimes()
+	signatureGenerator.EXPECT().GenerateSignature(gomock.AssignableToTypeOf(auth.SignatureRequest{})).Return("some signature", nil).AnyTimes()
+
+	ws, err := client.NewUserWebsocket(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	return ws
+}
+
+// acceptAuth reads the public/auth request for a new connection and responds successfully.
+func acceptAuth(t *testing.T, conn *websocket.Conn) bool {
+	req, ok := readRequest(conn)
+	if !ok {
+		return false
+	}
+	if !assert.Equal(t, cdcexchange.MethodAuth, req.Method) {
+		return false
+	}
+	writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+	return true
+}
+
+func TestUserWebsocket_EnableCancelOnDisconnect_Error(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        cdcexchange.CancelOnDisconnectScope
+		responseCode int64
+		expectedErr  error
+	}{
+		{
+			name:  "returns error when scope is empty",
+			scope: "",
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "scope",
+				Reason:    "must be ACCOUNT or CONNECTION",
+			},
+		},
+		{
+			name:  "returns error when scope is not supported",
+			scope: "ORDER",
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "scope",
+				Reason:    "must be ACCOUNT or CONNECTION",
+			},
+		},
+		{
+			name:         "returns error given error response",
+			scope:        cdcexchange.CancelOnDisconnectScopeAccount,
+			responseCode: 10004,
+			expectedErr:  cdcerrors.ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				if !acceptAuth(t, conn) {
+					return
+				}
+
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+				writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method, Code: tt.responseCode})
+				waitForClose(conn)
+			})
+
+			ctx := context.Background()
+			ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClock())
+
+			err := ws.EnableCancelOnDisconnect(ctx, tt.scope)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+		})
+	}
+}
+
+func TestUserWebsocket_CancelOnDisconnect_Success(t *testing.T) {
+	now := time.Now()
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		if !acceptAuth(t, conn) {
+			return
+		}
+
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, cdcexchange.MethodSetCancelOnDisconnect, req.Method)
+		assert.Equal(t, now.UnixMilli(), req.Nonce)
+		assert.Equal(t, map[string]interface{}{"scope": "CONNECTION"}, req.Params)
+		writeResponse(t, conn, websocketResponse{
+			ID:     req.ID,
+			Method: req.Method,
+			Result: map[string]interface{}{"scope": "CONNECTION"},
+		})
+
+		req, ok = readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, cdcexchange.MethodGetCancelOnDisconnect, req.Method)
+		assert.Empty(t, req.Params)
+		writeResponse(t, conn, websocketResponse{
+			ID:     req.ID,
+			Method: req.Method,
+			Result: map[string]interface{}{"scope": "CONNECTION"},
+		})
+
+		waitForClose(conn)
+	})
+
+	ctx := context.Background()
+	ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClockAt(now))
+
+	require.NoError(t, ws.EnableCancelOnDisconnect(ctx, cdcexchange.CancelOnDisconnectScopeConnection))
+
+	scope, err := ws.GetCancelOnDisconnect(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, cdcexchange.CancelOnDisconnectScopeConnection, scope)
+}
+
+func TestUserWebsocket_CancelOnDisconnect_Reconnect(t *testing.T) {
+	const maxBackoff = 10 * time.Second
+
+	tests := []struct {
+		name     string
+		setCode  int64
+		expected []string
+	}{
+		{
+			name:     "re-applies scope when the connection is re-established",
+			expected: []string{cdcexchange.MethodAuth, cdcexchange.MethodSetCancelOnDisconnect},
+		},
+		{
+			name:     "does not re-apply scope which failed to be enabled",
+			setCode:  10004,
+			expected: []string{cdcexchange.MethodAuth, "subscribe"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				connections int32
+				reconnected = make(chan []string, 1)
+			)
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				if atomic.AddInt32(&connections, 1) == 1 {
+					if !acceptAuth(t, conn) {
+						return
+					}
+
+					req, ok := readRequest(conn)
+					if !ok {
+						return
+					}
+					assert.Equal(t, cdcexchange.MethodSetCancelOnDisconnect, req.Method)
+					writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method, Code: tt.setCode})
+
+					req, ok = readRequest(conn)
+					if !ok {
+						return
+					}
+					assert.Equal(t, "subscribe", req.Method)
+					writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+					// drop the first connection once subscribed.
+					return
+				}
+
+				var methods []string
+				for len(methods) < 2 {
+					req, ok := readRequest(conn)
+					if !ok {
+						return
+					}
+					methods = append(methods, req.Method)
+					writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+				}
+				reconnected <- methods
+
+				waitForClose(conn)
+			})
+
+			var (
+				ctx   = context.Background()
+				clock = clockwork.NewFakeClock()
+			)
+			ws := newCancelOnDisconnectWebsocket(t, ctx, url, clock,
+				cdcexchange.WithWebsocketReconnectBackoff(time.Second, maxBackoff),
+			)
+
+			assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+
+			err := ws.EnableCancelOnDisconnect(ctx, cdcexchange.CancelOnDisconnectScopeAccount)
+			if tt.setCode != 0 {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = ws.SubscribeBalance(ctx)
+			require.NoError(t, err)
+
+			assertEvent(t, ws.Events(), cdcexchange.WebsocketEventReconnecting)
+
+			clock.BlockUntil(1)
+			clock.Advance(maxBackoff)
+
+			select {
+			case methods := <-reconnected:
+				assert.Equal(t, tt.expected, methods)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for connection to be re-established")
+			}
+		})
+	}
+}
+
+func TestUserWebsocket_DisableCancelOnDisconnect(t *testing.T) {
+	t.Run("does nothing if cancel on disconnect is not enabled", func(t *testing.T) {
+		var connections int32
+		url := newWebsocketServer(t, func(conn *websocket.Conn) {
+			atomic.AddInt32(&connections, 1)
+			if !acceptAuth(t, conn) {
+				return
+			}
+			waitForClose(conn)
+		})
+
+		ctx := context.Background()
+		ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClock())
+
+		require.NoError(t, ws.DisableCancelOnDisconnect(ctx))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+	})
+
+	t.Run("replaces the session without the scope", func(t *testing.T) {
+		var (
+			connections int32
+			closed      = make(chan struct{})
+			replaced    = make(chan []string, 1)
+		)
+		url := newWebsocketServer(t, func(conn *websocket.Conn) {
+			if atomic.AddInt32(&connections, 1) == 1 {
+				for _, method := range []string{cdcexchange.MethodAuth, cdcexchange.MethodSetCancelOnDisconnect, "subscribe"} {
+					req, ok := readRequest(conn)
+					if !ok {
+						return
+					}
+					assert.Equal(t, method, req.Method)
+					writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+				}
+
+				// the previous session is closed once it has been replaced.
+				waitForClose(conn)
+				close(closed)
+				return
+			}
+
+			var methods []string
+			for len(methods) < 3 {
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+				methods = append(methods, req.Method)
+				writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method, Result: map[string]interface{}{}})
+			}
+			replaced <- methods
+
+			waitForClose(conn)
+		})
+
+		ctx := context.Background()
+		// the session is replaced even though lost connections are not re-established.
+		ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClock())
+
+		require.NoError(t, ws.EnableCancelOnDisconnect(ctx, cdcexchange.CancelOnDisconnectScopeAccount))
+
+		_, err := ws.SubscribeBalance(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, ws.DisableCancelOnDisconnect(ctx))
+
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the previous session to be closed")
+		}
+
+		// requests are sent on the new session, which has not had the scope applied.
+		_, err = ws.GetCancelOnDisconnect(ctx)
+		require.NoError(t, err)
+
+		select {
+		case methods := <-replaced:
+			assert.Equal(t, []string{cdcexchange.MethodAuth, "subscribe", cdcexchange.MethodGetCancelOnDisconnect}, methods)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the session to be replaced")
+		}
+	})
+
+	t.Run("keeps the scope if the session cannot be replaced", func(t *testing.T) {
+		var connections int32
+		url := newWebsocketServer(t, func(conn *websocket.Conn) {
+			if atomic.AddInt32(&connections, 1) > 1 {
+				// the new session fails to authenticate.
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+				writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method, Code: 10002})
+				waitForClose(conn)
+				return
+			}
+
+			for {
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+				writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method, Result: map[string]interface{}{"scope": "CONNECTION"}})
+			}
+		})
+
+		ctx := context.Background()
+		ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClock())
+
+		require.NoError(t, ws.EnableCancelOnDisconnect(ctx, cdcexchange.CancelOnDisconnectScopeConnection))
+
+		err := ws.DisableCancelOnDisconnect(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, cdcerrors.ErrUnauthorized))
+
+		// requests are still sent on the previous session.
+		scope, err := ws.GetCancelOnDisconnect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, cdcexchange.CancelOnDisconnectScopeConnection, scope)
+
+		// the scope is kept, so disabling it is attempted again.
+		require.Error(t, ws.DisableCancelOnDisconnect(ctx))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&connections))
+	})
+}
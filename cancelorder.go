@@ -25,6 +25,11 @@ type CancelOrderResponse struct {
 //
 // Method: private/cancel-order
 func (c *client) CancelOrder(ctx context.Context, instrumentName string, orderID string) error {
+	return c.cancelOrder(ctx, methodCancelOrder, instrumentName, orderID)
+}
+
+// cancelOrder cancels an order via the specified cancel-order method (spot or margin).
+func (c *client) cancelOrder(ctx context.Context, method string, instrumentName string, orderID string) error {
 	if instrumentName == "" {
 		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
 	}
@@ -34,7 +39,7 @@ func (c *client) CancelOrder(ctx context.Context, instrumentName string, orderID
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -45,7 +50,7 @@ func (c *client) CancelOrder(ctx context.Context, instrumentName string, orderID
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodCancelOrder,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -55,7 +60,7 @@ func (c *client) CancelOrder(ctx context.Context, instrumentName string, orderID
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodCancelOrder,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -63,7 +68,7 @@ func (c *client) CancelOrder(ctx context.Context, instrumentName string, orderID
 	}
 
 	var cancelOrderResponse CancelOrderResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCancelOrder, &cancelOrderResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &cancelOrderResponse)
 	if err != nil {
 		return fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -0,0 +1,358 @@
+package cdcexchangetest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+const (
+	// loanStatusActive & loanStatusRepaid are the statuses of a loan before & after it has been repaid.
+	loanStatusActive = "ACTIVE"
+	loanStatusRepaid = "REPAID"
+
+	// statusCompleted is the status of a completed transfer or repayment.
+	statusCompleted = "COMPLETED"
+)
+
+var (
+	// maxBorrow is the maximum amount of each currency which can be borrowed by the MARGIN wallet.
+	maxBorrow = decimal.MustParse("1000000")
+	// dailyInterestRate is the daily interest rate reported for each currency, interest is never charged.
+	dailyInterestRate = decimal.MustParse("0.0005")
+)
+
+// walletTransfer is a transfer between the SPOT wallet & the MARGIN or DERIVATIVES wallet.
+type walletTransfer struct {
+	// wallet is the MARGIN or DERIVATIVES wallet, the direction of the transfer is relative to it.
+	wallet    string
+	direction cdcexchange.TransferDirection
+	currency  string
+	amount    decimal.Decimal
+	time      cdctime.Time
+}
+
+// getMarginCurrencies returns the currencies of the instruments with margin trading enabled,
+// which can be both borrowed & transferred.
+func (s *Server) getMarginCurrencies(_ *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	return s.marginCurrencies(), 0
+}
+
+// transferWallet moves funds between the SPOT wallet & another wallet of the account.
+// Funds cannot be transferred out of the MARGIN wallet while it has an active loan.
+func (s *Server) transferWallet(wallet string, params map[string]interface{}) (interface{}, int64) {
+	var (
+		currency = stringParam(params, "currency")
+		from     = stringParam(params, "from")
+		to       = stringParam(params, "to")
+	)
+	amount, ok := decimalParam(params, "amount")
+	if !ok || currency == "" || amount.Sign() <= 0 {
+		return nil, codeBadRequest
+	}
+
+	direction := cdcexchange.TransferDirectionIn
+	switch {
+	case from == walletSpot && to == wallet:
+	case from == wallet && to == walletSpot:
+		direction = cdcexchange.TransferDirectionOut
+	default:
+		return nil, codeBadRequest
+	}
+
+	if wallet == walletMargin && direction == cdcexchange.TransferDirectionOut && len(s.borrowed) > 0 {
+		return nil, codeActiveLoan
+	}
+
+	if !s.move(from, to, currency, amount) {
+		return nil, codeNoBalance
+	}
+
+	s.walletTransfers = append(s.walletTransfers, walletTransfer{
+		wallet:    wallet,
+		direction: direction,
+		currency:  currency,
+		amount:    amount,
+		time:      cdctime.Time(s.clock.Now()),
+	})
+
+	return nil, 0
+}
+
+// getWalletTransferHistory returns the transfers in & out of the MARGIN or DERIVATIVES wallet, most recent first.
+func (s *Server) getWalletTransferHistory(wallet string, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+	direction := cdcexchange.TransferDirection(stringParam(params, "direction"))
+
+	var transfers []walletTransfer
+	for i := len(s.walletTransfers) - 1; i >= 0; i-- {
+		t := s.walletTransfers[i]
+		if t.wallet == wallet && (direction == "" || t.direction == direction) && filter.matchCurrency(t.currency, t.time) {
+			transfers = append(transfers, t)
+		}
+	}
+	transfers = page(filter, transfers)
+
+	if wallet == walletDerivatives {
+		records := make([]cdcexchange.DerivTransferRecord, 0, len(transfers))
+		for _, t := range transfers {
+			records = append(records, cdcexchange.DerivTransferRecord(t.record()))
+		}
+		return cdcexchange.GetDerivTransferHistoryResult{TransferList: records}, 0
+	}
+
+	records := make([]cdcexchange.MarginTransferRecord, 0, len(transfers))
+	for _, t := range transfers {
+		records = append(records, t.record())
+	}
+	return cdcexchange.GetMarginTransferHistoryResult{TransferList: records}, 0
+}
+
+// record returns the record of the transfer returned by the history of its wallet.
+func (t walletTransfer) record() cdcexchange.MarginTransferRecord {
+	information := "From Spot Wallet"
+	if t.direction == cdcexchange.TransferDirectionOut {
+		information = "To Spot Wallet"
+	}
+
+	return cdcexchange.MarginTransferRecord{
+		Direction:   t.direction,
+		Time:        t.time,
+		Amount:      t.amount,
+		Status:      statusCompleted,
+		Information: information,
+		Currency:    t.currency,
+	}
+}
+
+// marginBorrow borrows an amount of a margin currency into the MARGIN wallet.
+func (s *Server) marginBorrow(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	currency := stringParam(params, "currency")
+	amount, ok := decimalParam(params, "amount")
+	if !ok || amount.Sign() <= 0 || !s.isMarginCurrency(currency) {
+		return nil, codeBadRequest
+	}
+
+	borrowed := s.borrowed[currency].Add(amount)
+	if borrowed.GreaterThan(maxBorrow) {
+		return nil, codeMaxAmount
+	}
+	s.borrowed[currency] = borrowed
+
+	account := s.account(walletMargin, currency)
+	account.Balance = account.Balance.Add(amount)
+	account.Available = account.Available.Add(amount)
+
+	s.lastID++
+	s.borrows = append(s.borrows, cdcexchange.MarginBorrowRecord{
+		LoanID:     strconv.FormatInt(s.lastID, 10),
+		Currency:   currency,
+		LoanAmount: amount,
+		BorrowTime: cdctime.Time(s.clock.Now()),
+		Status:     loanStatusActive,
+	})
+
+	return nil, 0
+}
+
+// marginRepay repays up to amount of the loans of a currency from the MARGIN wallet,
+// the loans of the currency are repaid once nothing more is borrowed.
+func (s *Server) marginRepay(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	currency := stringParam(params, "currency")
+	amount, ok := decimalParam(params, "amount")
+	if !ok || currency == "" || amount.Sign() <= 0 {
+		return nil, codeBadRequest
+	}
+
+	borrowed, ok := s.borrowed[currency]
+	if !ok {
+		return nil, codeNoActiveLoan
+	}
+
+	amount = minDecimal(amount, borrowed)
+	account := s.account(walletMargin, currency)
+	if amount.GreaterThan(account.Available) {
+		return nil, codeNoBalance
+	}
+	account.Balance = account.Balance.Sub(amount)
+	account.Available = account.Available.Sub(amount)
+
+	if borrowed = borrowed.Sub(amount); borrowed.IsZero() {
+		delete(s.borrowed, currency)
+		for i := range s.borrows {
+			if s.borrows[i].Currency == currency {
+				s.borrows[i].Status = loanStatusRepaid
+			}
+		}
+	} else {
+		s.borrowed[currency] = borrowed
+	}
+
+	s.repays = append(s.repays, cdcexchange.MarginRepayRecord{
+		Currency:    currency,
+		RepayAmount: amount,
+		Principal:   amount,
+		Interest:    decimal.Zero,
+		RepayTime:   cdctime.Time(s.clock.Now()),
+		Status:      statusCompleted,
+	})
+
+	return nil, 0
+}
+
+func (s *Server) getMarginUserConfig(_ *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	configs := make(map[string]cdcexchange.MarginCurrencyConfig)
+	for _, currency := range s.marginCurrencies() {
+		configs[currency] = cdcexchange.MarginCurrencyConfig{
+			DailyInterestRate: dailyInterestRate,
+			MaxBorrowLimit:    maxBorrow,
+		}
+	}
+
+	return cdcexchange.MarginUserConfig{CurrencyConfigs: configs}, 0
+}
+
+// getMarginAccountSummary returns the balances & loans of the MARGIN wallet, totals are not valued.
+func (s *Server) getMarginAccountSummary(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	currency := stringParam(params, "currency")
+
+	accounts := make([]cdcexchange.MarginAccount, 0, len(s.wallets[walletMargin]))
+	for _, account := range s.wallets[walletMargin] {
+		if currency != "" && account.Currency != currency {
+			continue
+		}
+
+		borrowed := s.borrowed[account.Currency]
+		accounts = append(accounts, cdcexchange.MarginAccount{
+			Balance:   account.Balance,
+			Available: account.Available,
+			Order:     account.Order,
+			Borrowed:  borrowed,
+			Position:  account.Balance.Sub(borrowed),
+			Currency:  account.Currency,
+		})
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Currency < accounts[j].Currency
+	})
+
+	return cdcexchange.MarginAccountSummary{Accounts: accounts, MarginScore: "GOOD"}, 0
+}
+
+// getMarginBorrowHistory returns the loans of the MARGIN wallet, most recent first.
+func (s *Server) getMarginBorrowHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	borrows := make([]cdcexchange.MarginBorrowRecord, 0)
+	for i := len(s.borrows) - 1; i >= 0; i-- {
+		if b := s.borrows[i]; filter.matchCurrency(b.Currency, b.BorrowTime) {
+			borrows = append(borrows, b)
+		}
+	}
+
+	return cdcexchange.GetMarginBorrowHistoryResult{BorrowList: page(filter, borrows)}, 0
+}
+
+// getMarginInterestHistory returns no interest charges, as interest is never charged.
+func (s *Server) getMarginInterestHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	if _, ok := newFilter(params); !ok {
+		return nil, codeBadRequest
+	}
+
+	return cdcexchange.GetMarginInterestHistoryResult{InterestList: make([]cdcexchange.MarginInterestRecord, 0)}, 0
+}
+
+// getMarginRepayHistory returns the repayments of the MARGIN wallet, most recent first.
+func (s *Server) getMarginRepayHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	repays := make([]cdcexchange.MarginRepayRecord, 0)
+	for i := len(s.repays) - 1; i >= 0; i-- {
+		if r := s.repays[i]; filter.matchCurrency(r.Currency, r.RepayTime) {
+			repays = append(repays, r)
+		}
+	}
+
+	return cdcexchange.GetMarginRepayHistoryResult{RepayList: page(filter, repays)}, 0
+}
+
+// getMarginLiquidationHistory returns no liquidations, as the MARGIN wallet is never liquidated.
+func (s *Server) getMarginLiquidationHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	if _, ok := newFilter(params); !ok {
+		return nil, codeBadRequest
+	}
+
+	return cdcexchange.GetMarginLiquidationHistoryResult{LiquidationList: make([]cdcexchange.MarginLiquidationRecord, 0)}, 0
+}
+
+// getMarginLiquidationOrders returns no orders, as the MARGIN wallet is never liquidated.
+func (s *Server) getMarginLiquidationOrders(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	if _, ok := newFilter(params); !ok {
+		return nil, codeBadRequest
+	}
+
+	return cdcexchange.GetMarginLiquidationOrdersResult{OrderList: make([]cdcexchange.Order, 0)}, 0
+}
+
+// marginCurrencies returns the currencies of the instruments with margin trading enabled, in alphabetical order.
+func (s *Server) marginCurrencies() []string {
+	seen := make(map[string]bool)
+	currencies := make([]string, 0)
+	for _, instrument := range s.instruments {
+		if !instrument.MarginTradingEnabled {
+			continue
+		}
+
+		for _, currency := range []string{instrument.BaseCurrency, instrument.QuoteCurrency} {
+			if !seen[currency] {
+				seen[currency] = true
+				currencies = append(currencies, currency)
+			}
+		}
+	}
+
+	sort.Strings(currencies)
+	return currencies
+}
+
+// isMarginCurrency reports whether a currency can be borrowed by the MARGIN wallet.
+func (s *Server) isMarginCurrency(currency string) bool {
+	for _, c := range s.marginCurrencies() {
+		if c == currency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// move moves amount of a currency between the available balances of two wallets,
+// returning false if the wallet it is moved from cannot afford it.
+func (s *Server) move(from, to, currency string, amount decimal.Decimal) bool {
+	source := s.account(from, currency)
+	if amount.GreaterThan(source.Available) {
+		return false
+	}
+	source.Balance = source.Balance.Sub(amount)
+	source.Available = source.Available.Sub(amount)
+
+	destination := s.account(to, currency)
+	destination.Balance = destination.Balance.Add(amount)
+	destination.Available = destination.Available.Add(amount)
+
+	return true
+}
@@ -0,0 +1,165 @@
+package cdcexchangetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/cdcexchangetest"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+func TestServer_Margin(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("1000")))
+
+	currencies, err := client.GetMarginLoanCurrencies(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC", "CRO", "ETH", "USDT"}, currencies)
+
+	err = client.MarginTransfer(ctx, cdcexchange.MarginTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.MarginWalletSpot,
+		To:       cdcexchange.MarginWalletMargin,
+		Amount:   d("400"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, d("600"), s.Account("USDT").Available)
+	assert.Equal(t, d("400"), s.WalletAccount("MARGIN", "USDT").Available)
+
+	require.NoError(t, client.MarginBorrow(ctx, "USDT", d("100")))
+
+	summary, err := client.GetMarginAccountSummary(ctx, "USDT")
+	require.NoError(t, err)
+	require.Len(t, summary.Accounts, 1)
+	assert.Equal(t, d("500"), summary.Accounts[0].Balance)
+	assert.Equal(t, d("100"), summary.Accounts[0].Borrowed)
+	assert.Equal(t, d("400"), summary.Accounts[0].Position)
+
+	// funds cannot leave the margin wallet while it has an active loan.
+	err = client.MarginTransfer(ctx, cdcexchange.MarginTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.MarginWalletMargin,
+		To:       cdcexchange.MarginWalletSpot,
+		Amount:   d("100"),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrMGTransferActiveLoan))
+
+	// margin orders are held in the margin wallet, separately from spot orders.
+	s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("100"))
+	res, err := client.CreateMarginOrder(ctx, limitOrder(cdcexchange.OrderSideBuy, "0.5", "100"))
+	require.NoError(t, err)
+
+	trades, err := client.GetMarginTrades(ctx, cdcexchange.GetTradesRequest{})
+	require.NoError(t, err)
+	require.Len(t, trades.TradeList, 1)
+	assert.Equal(t, res.OrderID, trades.TradeList[0].OrderID)
+
+	spotTrades, err := client.GetTrades(ctx, cdcexchange.GetTradesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, spotTrades.TradeList)
+
+	assert.Equal(t, d("450"), s.WalletAccount("MARGIN", "USDT").Available)
+	assert.Equal(t, d("99.9"), s.WalletAccount("MARGIN", "CRO").Available)
+	assert.Equal(t, d("600"), s.Account("USDT").Available)
+
+	require.NoError(t, client.MarginRepay(ctx, "USDT", d("100")))
+
+	err = client.MarginRepay(ctx, "USDT", d("100"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrMGNoActiveLoan))
+
+	borrows, err := client.GetMarginBorrowHistory(ctx, cdcexchange.GetMarginBorrowHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, borrows.BorrowList, 1)
+	assert.Equal(t, "USDT", borrows.BorrowList[0].Currency)
+	assert.Equal(t, d("100"), borrows.BorrowList[0].LoanAmount)
+	assert.Equal(t, "REPAID", borrows.BorrowList[0].Status)
+
+	repays, err := client.GetMarginRepayHistory(ctx, cdcexchange.GetMarginRepayHistoryRequest{Currency: "USDT"})
+	require.NoError(t, err)
+	require.Len(t, repays.RepayList, 1)
+	assert.Equal(t, d("100"), repays.RepayList[0].RepayAmount)
+
+	err = client.MarginTransfer(ctx, cdcexchange.MarginTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.MarginWalletMargin,
+		To:       cdcexchange.MarginWalletSpot,
+		Amount:   d("350"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, d("950"), s.Account("USDT").Available)
+
+	transfers, err := client.GetMarginTransferHistory(ctx, cdcexchange.GetMarginTransferHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, transfers.TransferList, 2)
+	assert.Equal(t, cdcexchange.TransferDirectionOut, transfers.TransferList[0].Direction)
+	assert.Equal(t, d("350"), transfers.TransferList[0].Amount)
+	assert.Equal(t, cdcexchange.TransferDirectionIn, transfers.TransferList[1].Direction)
+	assert.Equal(t, d("400"), transfers.TransferList[1].Amount)
+
+	transfers, err = client.GetMarginTransferHistory(ctx, cdcexchange.GetMarginTransferHistoryRequest{
+		Direction: cdcexchange.TransferDirectionIn,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers.TransferList, 1)
+	assert.Equal(t, d("400"), transfers.TransferList[0].Amount)
+}
+
+func TestServer_MarginBorrow(t *testing.T) {
+	ctx := context.Background()
+	_, client := newServer(t)
+
+	err := client.MarginBorrow(ctx, "DOGE", d("1"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrBadRequest))
+
+	config, err := client.GetMarginUserConfig(ctx)
+	require.NoError(t, err)
+	require.Contains(t, config.CurrencyConfigs, "BTC")
+
+	err = client.MarginBorrow(ctx, "BTC", config.CurrencyConfigs["BTC"].MaxBorrowLimit.Add(d("1")))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrMaxAmountViolated))
+}
+
+func TestServer_DerivTransfer(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("100")))
+
+	err := client.DerivTransfer(ctx, cdcexchange.DerivTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.DerivWalletSpot,
+		To:       cdcexchange.DerivWalletDerivatives,
+		Amount:   d("150"),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrNegativeBalance))
+
+	err = client.DerivTransfer(ctx, cdcexchange.DerivTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.DerivWalletSpot,
+		To:       cdcexchange.DerivWalletDerivatives,
+		Amount:   d("60"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, d("40"), s.Account("USDT").Available)
+	assert.Equal(t, d("60"), s.WalletAccount("DERIVATIVES", "USDT").Available)
+
+	transfers, err := client.GetDerivTransferHistory(ctx, cdcexchange.GetDerivTransferHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, transfers.TransferList, 1)
+	assert.Equal(t, cdcexchange.TransferDirectionIn, transfers.TransferList[0].Direction)
+	assert.Equal(t, "USDT", transfers.TransferList[0].Currency)
+	assert.Equal(t, d("60"), transfers.TransferList[0].Amount)
+
+	// transfers of the derivatives wallet are not in the history of the margin wallet.
+	marginTransfers, err := client.GetMarginTransferHistory(ctx, cdcexchange.GetMarginTransferHistoryRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, marginTransfers.TransferList)
+}
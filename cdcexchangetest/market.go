@@ -0,0 +1,349 @@
+package cdcexchangetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+// defaultBookDepth is the depth of the book returned if a depth is not specified.
+const defaultBookDepth = 150
+
+// handlers returns the handler of each supported method.
+func handlers() map[string]handler {
+	return map[string]handler{
+		"public/get-instruments":                  {handle: (*Server).getInstruments},
+		"public/get-book":                         {handle: (*Server).getBook},
+		"public/get-ticker":                       {handle: (*Server).getTicker},
+		"public/get-trades":                       {handle: (*Server).getPublicTrades},
+		"public/get-candlestick":                  {handle: (*Server).getCandlestick},
+		"public/margin/get-loan-currencies":       {handle: (*Server).getMarginCurrencies},
+		"public/margin/get-transfer-currencies":   {handle: (*Server).getMarginCurrencies},
+		"private/get-account-summary":             {private: true, handle: (*Server).getAccountSummary},
+		"private/create-order":                    {private: true, handle: inWallet(walletSpot, (*Server).createOrder)},
+		"private/cancel-order":                    {private: true, handle: inWallet(walletSpot, (*Server).cancelOrder)},
+		"private/cancel-all-orders":               {private: true, handle: inWallet(walletSpot, (*Server).cancelAllOrders)},
+		"private/get-order-history":               {private: true, handle: inWallet(walletSpot, (*Server).getOrderHistory)},
+		"private/get-open-orders":                 {private: true, handle: inWallet(walletSpot, (*Server).getOpenOrders)},
+		"private/get-order-detail":                {private: true, handle: inWallet(walletSpot, (*Server).getOrderDetail)},
+		"private/get-trades":                      {private: true, handle: inWallet(walletSpot, (*Server).getTrades)},
+		"private/margin/transfer":                 {private: true, handle: inWallet(walletMargin, (*Server).transferWallet)},
+		"private/margin/borrow":                   {private: true, handle: (*Server).marginBorrow},
+		"private/margin/repay":                    {private: true, handle: (*Server).marginRepay},
+		"private/margin/get-user-config":          {private: true, handle: (*Server).getMarginUserConfig},
+		"private/margin/get-account-summary":      {private: true, handle: (*Server).getMarginAccountSummary},
+		"private/margin/get-transfer-history":     {private: true, handle: inWallet(walletMargin, (*Server).getWalletTransferHistory)},
+		"private/margin/get-borrow-history":       {private: true, handle: (*Server).getMarginBorrowHistory},
+		"private/margin/get-interest-history":     {private: true, handle: (*Server).getMarginInterestHistory},
+		"private/margin/get-repay-history":        {private: true, handle: (*Server).getMarginRepayHistory},
+		"private/margin/get-liquidation-history":  {private: true, handle: (*Server).getMarginLiquidationHistory},
+		"private/margin/get-liquidation-orders":   {private: true, handle: (*Server).getMarginLiquidationOrders},
+		"private/margin/create-order":             {private: true, handle: inWallet(walletMargin, (*Server).createOrder)},
+		"private/margin/cancel-order":             {private: true, handle: inWallet(walletMargin, (*Server).cancelOrder)},
+		"private/margin/cancel-all-orders":        {private: true, handle: inWallet(walletMargin, (*Server).cancelAllOrders)},
+		"private/margin/get-order-history":        {private: true, handle: inWallet(walletMargin, (*Server).getOrderHistory)},
+		"private/margin/get-open-orders":          {private: true, handle: inWallet(walletMargin, (*Server).getOpenOrders)},
+		"private/margin/get-order-detail":         {private: true, handle: inWallet(walletMargin, (*Server).getOrderDetail)},
+		"private/margin/get-trades":               {private: true, handle: inWallet(walletMargin, (*Server).getTrades)},
+		"private/deriv/transfer":                  {private: true, handle: inWallet(walletDerivatives, (*Server).transferWallet)},
+		"private/deriv/get-transfer-history":      {private: true, handle: inWallet(walletDerivatives, (*Server).getWalletTransferHistory)},
+		"private/subaccount/get-sub-accounts":     {private: true, handle: (*Server).getSubAccounts},
+		"private/subaccount/transfer":             {private: true, handle: (*Server).subAccountTransfer},
+		"private/subaccount/get-transfer-history": {private: true, handle: (*Server).getSubAccountTransferHistory},
+		"private/get-deposit-address":             {private: true, handle: (*Server).getDepositAddress},
+		"private/get-deposit-history":             {private: true, handle: (*Server).getDepositHistory},
+		"private/create-withdrawal":               {private: true, handle: (*Server).createWithdrawal},
+		"private/get-withdrawal-history":          {private: true, handle: (*Server).getWithdrawalHistory},
+	}
+}
+
+// inWallet adapts a handler of requests to a wallet (e.g. orders) to the wallet of a method.
+func inWallet(wallet string, handle func(s *Server, wallet string, params map[string]interface{}) (interface{}, int64)) func(s *Server, r *http.Request, params map[string]interface{}) (interface{}, int64) {
+	return func(s *Server, _ *http.Request, params map[string]interface{}) (interface{}, int64) {
+		return handle(s, wallet, params)
+	}
+}
+
+func (s *Server) getInstruments(_ *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	return cdcexchange.InstrumentResult{Instruments: s.instruments}, 0
+}
+
+func (s *Server) getBook(r *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	q := r.URL.Query()
+
+	instrument, ok := s.instrument(q.Get("instrument_name"))
+	if !ok {
+		return nil, codeNoSymbol
+	}
+
+	depth := defaultBookDepth
+	if d := q.Get("depth"); d != "" {
+		var err error
+		if depth, err = strconv.Atoi(d); err != nil || depth <= 0 {
+			return nil, codeBadRequest
+		}
+	}
+
+	bids, asks := s.book(instrument.InstrumentName)
+
+	return cdcexchange.BookResult{
+		Bids:      levels(bids, depth),
+		Asks:      levels(asks, depth),
+		Timestamp: cdctime.Time(s.clock.Now()),
+	}, 0
+}
+
+func (s *Server) getTicker(r *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	name := r.URL.Query().Get("instrument_name")
+	if name == "" {
+		tickers := make([]cdcexchange.Ticker, 0, len(s.instruments))
+		for _, instrument := range s.instruments {
+			tickers = append(tickers, s.ticker(instrument.InstrumentName))
+		}
+		return cdcexchange.TickerResult{Data: tickers}, 0
+	}
+
+	instrument, ok := s.instrument(name)
+	if !ok {
+		return nil, codeNoSymbol
+	}
+
+	return cdcexchange.SingleTickerResult{Data: s.ticker(instrument.InstrumentName)}, 0
+}
+
+func (s *Server) getPublicTrades(r *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	name := r.URL.Query().Get("instrument_name")
+	if name != "" {
+		if _, ok := s.instrument(name); !ok {
+			return nil, codeNoSymbol
+		}
+	}
+
+	trades := make([]cdcexchange.MarketTrade, 0)
+	for i := len(s.marketTrades) - 1; i >= 0; i-- {
+		if name == "" || s.marketTrades[i].InstrumentName == name {
+			trades = append(trades, s.marketTrades[i])
+		}
+	}
+
+	return cdcexchange.PublicTradesResult{Data: trades}, 0
+}
+
+// intervals is the duration of each candlestick interval, months are handled separately as they vary in length.
+var intervals = map[cdcexchange.Interval]time.Duration{
+	cdcexchange.Interval1Minute:   time.Minute,
+	cdcexchange.Interval5Minutes:  5 * time.Minute,
+	cdcexchange.Interval15Minutes: 15 * time.Minute,
+	cdcexchange.Interval30Minutes: 30 * time.Minute,
+	cdcexchange.Interval1Hour:     time.Hour,
+	cdcexchange.Interval4Hours:    4 * time.Hour,
+	cdcexchange.Interval6Hours:    6 * time.Hour,
+	cdcexchange.Interval12Hours:   12 * time.Hour,
+	cdcexchange.Interval1Day:      24 * time.Hour,
+	cdcexchange.Interval7Days:     7 * 24 * time.Hour,
+	cdcexchange.Interval14Days:    14 * 24 * time.Hour,
+	cdcexchange.Interval1Month:    0,
+}
+
+// getCandlestick aggregates the trades of an instrument into candlesticks, oldest first.
+func (s *Server) getCandlestick(r *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	q := r.URL.Query()
+
+	instrument, ok := s.instrument(q.Get("instrument_name"))
+	if !ok {
+		return nil, codeNoSymbol
+	}
+
+	interval := cdcexchange.Interval(q.Get("timeframe"))
+	if interval == "" {
+		interval = cdcexchange.Interval5Minutes
+	}
+	d, ok := intervals[interval]
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	candlesticks := make([]cdcexchange.Candlestick, 0)
+	for _, trade := range s.marketTrades {
+		if trade.InstrumentName != instrument.InstrumentName {
+			continue
+		}
+
+		start := trade.Timestamp.Time().UTC()
+		if d == 0 {
+			start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		} else {
+			start = start.Truncate(d)
+		}
+
+		if n := len(candlesticks); n > 0 && candlesticks[n-1].Timestamp.Time().Equal(start) {
+			c := &candlesticks[n-1]
+			if trade.Price.GreaterThan(c.High) {
+				c.High = trade.Price
+			}
+			if trade.Price.LessThan(c.Low) {
+				c.Low = trade.Price
+			}
+			c.Close = trade.Price
+			c.Volume = c.Volume.Add(trade.Quantity)
+			continue
+		}
+
+		candlesticks = append(candlesticks, cdcexchange.Candlestick{
+			Timestamp: cdctime.Time(start),
+			Open:      trade.Price,
+			High:      trade.Price,
+			Low:       trade.Price,
+			Close:     trade.Price,
+			Volume:    trade.Quantity,
+		})
+	}
+
+	return cdcexchange.CandlestickResult{
+		InstrumentName: instrument.InstrumentName,
+		Interval:       interval,
+		Data:           candlesticks,
+	}, 0
+}
+
+// instrument looks up an instrument by name.
+func (s *Server) instrument(name string) (cdcexchange.Instrument, bool) {
+	for _, instrument := range s.instruments {
+		if instrument.InstrumentName == name {
+			return instrument, true
+		}
+	}
+
+	return cdcexchange.Instrument{}, false
+}
+
+// book returns the orders resting on the book of an instrument, with the best price of each side first.
+func (s *Server) book(instrumentName string) (bids, asks []*order) {
+	for _, o := range s.orders {
+		if o.InstrumentName != instrumentName || !o.resting() {
+			continue
+		}
+
+		if o.Side == cdcexchange.OrderSideBuy {
+			bids = append(bids, o)
+		} else {
+			asks = append(asks, o)
+		}
+	}
+
+	// orders at the same price are kept in the order they were created.
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Price.GreaterThan(bids[j].Price)
+	})
+	sort.SliceStable(asks, func(i, j int) bool {
+		return asks[i].Price.LessThan(asks[j].Price)
+	})
+
+	return bids, asks
+}
+
+// levels aggregates the orders of one side of the book into price levels, up to depth levels.
+func levels(orders []*order, depth int) [][]decimal.Decimal {
+	levels := make([][]decimal.Decimal, 0)
+	for _, o := range orders {
+		if n := len(levels); n > 0 && levels[n-1][0].Equal(o.Price) {
+			levels[n-1][1] = levels[n-1][1].Add(o.remaining())
+			levels[n-1][2] = levels[n-1][2].Add(decimal.NewFromInt(1))
+			continue
+		}
+
+		if len(levels) == depth {
+			break
+		}
+		levels = append(levels, []decimal.Decimal{o.Price, o.remaining(), decimal.NewFromInt(1)})
+	}
+
+	return levels
+}
+
+// ticker returns the ticker of an instrument, from the book & the trades of the last 24 hours.
+func (s *Server) ticker(instrumentName string) cdcexchange.Ticker {
+	now := s.clock.Now()
+	t := cdcexchange.Ticker{
+		Instrument: instrumentName,
+		Timestamp:  cdctime.Time(now),
+	}
+
+	bids, asks := s.book(instrumentName)
+	if len(bids) > 0 {
+		t.BidPrice = bids[0].Price
+	}
+	if len(asks) > 0 {
+		t.AskPrice = asks[0].Price
+	}
+
+	var first decimal.Decimal
+	for _, trade := range s.marketTrades {
+		if trade.InstrumentName != instrumentName {
+			continue
+		}
+
+		t.LatestTradePrice = trade.Price
+		if now.Sub(trade.Timestamp.Time()) > 24*time.Hour {
+			continue
+		}
+
+		if first.IsZero() {
+			first = trade.Price
+			t.PriceHigh24h, t.PriceLow24h = trade.Price, trade.Price
+		}
+		if trade.Price.GreaterThan(t.PriceHigh24h) {
+			t.PriceHigh24h = trade.Price
+		}
+		if trade.Price.LessThan(t.PriceLow24h) {
+			t.PriceLow24h = trade.Price
+		}
+		t.Volume24H = t.Volume24H.Add(trade.Quantity)
+	}
+
+	if !first.IsZero() {
+		t.PriceChange24h = t.LatestTradePrice.Sub(first)
+	}
+
+	return t
+}
+
+// stringParam returns the value of a param as a string, or "" if it is not set.
+func stringParam(params map[string]interface{}, key string) string {
+	switch v := params[key].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+// decimalParam returns the value of a param as a decimal, or 0 if it is not set.
+func decimalParam(params map[string]interface{}, key string) (decimal.Decimal, bool) {
+	v := stringParam(params, key)
+	if v == "" {
+		return decimal.Zero, true
+	}
+
+	d, err := decimal.Parse(v)
+	return d, err == nil
+}
+
+// intParam returns the value of a param as an int, or 0 if it is not set.
+func intParam(params map[string]interface{}, key string) (int64, bool) {
+	v := stringParam(params, key)
+	if v == "" {
+		return 0, true
+	}
+
+	i, err := strconv.ParseInt(v, 10, 64)
+	return i, err == nil
+}
@@ -0,0 +1,326 @@
+package cdcexchangetest
+
+import (
+	"strconv"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+const (
+	// reasonPostOnlyRejected is the reason of a POST_ONLY order which is rejected because it would have been a taker.
+	reasonPostOnlyRejected = "POST_ONLY_REJ"
+
+	// avgPriceDecimals is the number of decimal places of the average price of an order.
+	avgPriceDecimals = 8
+)
+
+var (
+	defaultMakerFee = decimal.MustParse("0.0004")
+	defaultTakerFee = decimal.MustParse("0.001")
+)
+
+// WithFees sets the fee rates of maker & taker trades (Default: 0.04% maker, 0.1% taker).
+//
+// Fees are charged in the currency received by the trade, the base currency of a buy & the quote currency of a sell.
+func WithFees(maker, taker decimal.Decimal) Option {
+	return func(s *Server) {
+		s.makerFee = maker
+		s.takerFee = taker
+	}
+}
+
+// AddLiquidity places a limit order on the book on behalf of another participant of the market,
+// which is matched against the book like an order of the account.
+//
+// Orders of the account are only matched against liquidity added by AddLiquidity, never against each other,
+// and liquidity added by AddLiquidity which matches other added liquidity only moves the price of the market.
+// Once the price of the market reaches the trigger price of an order, the order is triggered.
+func (s *Server) AddLiquidity(instrumentName string, side cdcexchange.OrderSide, price, quantity decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	now := cdctime.Time(s.clock.Now())
+	o := &order{
+		external: true,
+		Order: cdcexchange.Order{
+			Status:         cdcexchange.OrderStatusActive,
+			Side:           side,
+			Price:          price,
+			Quantity:       quantity,
+			OrderID:        strconv.FormatInt(s.lastID, 10),
+			CreateTime:     now,
+			UpdateTime:     now,
+			OrderType:      cdcexchange.OrderTypeLimit,
+			InstrumentName: instrumentName,
+		},
+	}
+	s.orders = append(s.orders, o)
+
+	s.match(o)
+	s.trigger(instrumentName)
+}
+
+// execute executes a new order, orders with a trigger price wait until they are triggered.
+func (s *Server) execute(o *order) {
+	if o.TriggerPrice.IsZero() {
+		s.match(o)
+	} else {
+		o.pending = true
+	}
+
+	s.trigger(o.InstrumentName)
+}
+
+// trigger triggers the pending orders of an instrument whose trigger price has been reached by the last trade.
+// STOP_LOSS & TAKE_PROFIT orders are executed as MARKET orders, STOP_LIMIT & TAKE_PROFIT_LIMIT orders as LIMIT orders.
+func (s *Server) trigger(instrumentName string) {
+	for {
+		price, ok := s.lastPrice(instrumentName)
+		if !ok {
+			return
+		}
+
+		var o *order
+		for _, pending := range s.orders {
+			if pending.pending && pending.Status == cdcexchange.OrderStatusActive &&
+				pending.InstrumentName == instrumentName && triggered(pending, price) {
+				o = pending
+				break
+			}
+		}
+		if o == nil {
+			return
+		}
+
+		// the trades of a triggered order may trigger further orders.
+		o.pending = false
+		s.match(o)
+	}
+}
+
+// triggered reports whether the trigger price of an order has been reached.
+// Stop orders trigger once the price rises to the trigger price of a buy or falls to the trigger price of a sell,
+// take profit orders trigger in the opposite direction.
+func triggered(o *order, price decimal.Decimal) bool {
+	stop := o.OrderType == cdcexchange.OrderTypeStopLoss || o.OrderType == cdcexchange.OrderTypeStopLimit
+	if stop == (o.Side == cdcexchange.OrderSideBuy) {
+		return !price.LessThan(o.TriggerPrice)
+	}
+
+	return !price.GreaterThan(o.TriggerPrice)
+}
+
+// lastPrice returns the price of the last trade of an instrument.
+func (s *Server) lastPrice(instrumentName string) (decimal.Decimal, bool) {
+	for i := len(s.marketTrades) - 1; i >= 0; i-- {
+		if s.marketTrades[i].InstrumentName == instrumentName {
+			return s.marketTrades[i].Price, true
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// match matches an order against the book, best price first, honouring its time in force & exec inst.
+// The remainder of a LIMIT order which is good till cancelled rests on the book, the remainder of any other order is cancelled.
+func (s *Server) match(taker *order) {
+	makers := s.makers(taker)
+
+	if taker.ExecInst == cdcexchange.ExecInstPostOnly && len(makers) > 0 {
+		taker.Reason = reasonPostOnlyRejected
+		s.closeOrder(taker, cdcexchange.OrderStatusRejected)
+		return
+	}
+
+	if taker.TimeInForce == cdcexchange.TimeInForceFillOrKill {
+		available := decimal.Zero
+		for _, maker := range makers {
+			available = available.Add(maker.remaining())
+		}
+		if available.LessThan(taker.Quantity) {
+			s.closeOrder(taker, cdcexchange.OrderStatusCancelled)
+			return
+		}
+	}
+
+	var (
+		instrument, _ = s.instrument(taker.InstrumentName)
+		spent         bool
+	)
+	for _, maker := range makers {
+		quantity := maker.remaining()
+		if taker.Quantity.IsZero() {
+			// an order for a notional amount buys as much as the remaining amount can buy at each price,
+			// the amount is spent once it cannot buy all of the quantity at a price.
+			affordable := taker.notional.Sub(taker.CumulativeValue).Div(maker.Price, int32(instrument.QuantityDecimals))
+			if affordable.LessThan(quantity) {
+				quantity, spent = affordable, true
+			}
+		} else {
+			quantity = minDecimal(quantity, taker.remaining())
+		}
+
+		if quantity.IsZero() || !s.fill(taker, maker, maker.Price, quantity) || spent {
+			break
+		}
+		if taker.Status != cdcexchange.OrderStatusActive {
+			return
+		}
+	}
+
+	if taker.Quantity.IsZero() && taker.notional.Equal(taker.CumulativeValue) {
+		spent = true
+	}
+
+	switch {
+	case spent && !taker.CumulativeQuantity.IsZero():
+		// the remainder of the notional amount is too small to buy any more.
+		s.closeOrder(taker, cdcexchange.OrderStatusFilled)
+	case taker.Price.IsZero(),
+		taker.TimeInForce == cdcexchange.TimeInForceImmediateOrCancel,
+		taker.TimeInForce == cdcexchange.TimeInForceFillOrKill:
+		s.closeOrder(taker, cdcexchange.OrderStatusCancelled)
+	}
+}
+
+// makers returns the resting orders which an order can be matched against, best price first.
+func (s *Server) makers(taker *order) []*order {
+	bids, asks := s.book(taker.InstrumentName)
+
+	book := asks
+	if taker.Side == cdcexchange.OrderSideSell {
+		book = bids
+	}
+
+	var makers []*order
+	for _, maker := range book {
+		if !taker.Price.IsZero() && (taker.Side == cdcexchange.OrderSideBuy && maker.Price.GreaterThan(taker.Price) ||
+			taker.Side == cdcexchange.OrderSideSell && maker.Price.LessThan(taker.Price)) {
+			break
+		}
+
+		// orders of the account are never matched against each other.
+		if !maker.external && !taker.external {
+			continue
+		}
+
+		makers = append(makers, maker)
+	}
+
+	return makers
+}
+
+// fill executes a trade between two orders, returning false if the account cannot afford its side of the trade.
+func (s *Server) fill(taker, maker *order, price, quantity decimal.Decimal) bool {
+	if !taker.external && !s.reserve(taker, price.Mul(quantity)) {
+		return false
+	}
+
+	s.lastID++
+	tradeID := s.lastID
+	now := cdctime.Time(s.clock.Now())
+
+	for _, o := range []*order{taker, maker} {
+		liquidity := cdcexchange.LiquidityIndicatorTaker
+		if o == maker {
+			liquidity = cdcexchange.LiquidityIndicatorMaker
+		}
+
+		o.CumulativeQuantity = o.CumulativeQuantity.Add(quantity)
+		o.CumulativeValue = o.CumulativeValue.Add(price.Mul(quantity))
+		o.AvgPrice = o.CumulativeValue.Div(o.CumulativeQuantity, avgPriceDecimals)
+		o.UpdateTime = now
+
+		if !o.external {
+			s.settle(o, tradeID, price, quantity, liquidity)
+		}
+
+		if !o.Quantity.IsZero() && o.remaining().IsZero() {
+			s.closeOrder(o, cdcexchange.OrderStatusFilled)
+		}
+	}
+
+	s.marketTrades = append(s.marketTrades, cdcexchange.MarketTrade{
+		TradeID:        tradeID,
+		InstrumentName: taker.InstrumentName,
+		Side:           taker.Side,
+		Price:          price,
+		Quantity:       quantity,
+		Timestamp:      now,
+	})
+
+	return true
+}
+
+// reserve ensures that a buy order holds enough of the balance to spend amount,
+// MARKET orders for a quantity hold nothing until they are matched as their cost is not known in advance.
+func (s *Server) reserve(o *order, amount decimal.Decimal) bool {
+	if o.Side != cdcexchange.OrderSideBuy || !amount.GreaterThan(o.locked) {
+		return true
+	}
+
+	shortfall := amount.Sub(o.locked)
+	account := s.account(o.wallet, o.lockedCurrency)
+	if shortfall.GreaterThan(account.Available) {
+		return false
+	}
+
+	account.Available = account.Available.Sub(shortfall)
+	account.Order = account.Order.Add(shortfall)
+	o.locked = amount
+
+	return true
+}
+
+// settle updates the balances of the account for its side of a trade & records the trade.
+func (s *Server) settle(o *order, tradeID int64, price, quantity decimal.Decimal, liquidity cdcexchange.LiquidityIndicator) {
+	instrument, _ := s.instrument(o.InstrumentName)
+
+	rate := s.takerFee
+	if liquidity == cdcexchange.LiquidityIndicatorMaker {
+		rate = s.makerFee
+	}
+
+	// the held balance is spent, the received balance is available less the fee.
+	spent, received := quantity, price.Mul(quantity)
+	spentCurrency, receivedCurrency := instrument.BaseCurrency, instrument.QuoteCurrency
+	if o.Side == cdcexchange.OrderSideBuy {
+		spent, received = received, spent
+		spentCurrency, receivedCurrency = receivedCurrency, spentCurrency
+	}
+	fee := received.Mul(rate)
+
+	o.locked = o.locked.Sub(spent)
+	account := s.account(o.wallet, spentCurrency)
+	account.Balance = account.Balance.Sub(spent)
+	account.Order = account.Order.Sub(spent)
+
+	account = s.account(o.wallet, receivedCurrency)
+	account.Balance = account.Balance.Add(received.Sub(fee))
+	account.Available = account.Available.Add(received.Sub(fee))
+
+	s.trades[o.wallet] = append(s.trades[o.wallet], cdcexchange.Trade{
+		Side:               o.Side,
+		InstrumentName:     o.InstrumentName,
+		Fee:                fee,
+		TradeID:            strconv.FormatInt(tradeID, 10),
+		CreateTime:         o.UpdateTime,
+		TradedPrice:        price,
+		TradedQuantity:     quantity,
+		FeeCurrency:        receivedCurrency,
+		OrderID:            o.OrderID,
+		ClientOrderID:      o.ClientOID,
+		LiquidityIndicator: liquidity,
+	})
+}
+
+func minDecimal(x, y decimal.Decimal) decimal.Decimal {
+	if x.LessThan(y) {
+		return x
+	}
+
+	return y
+}
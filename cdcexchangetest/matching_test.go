@@ -0,0 +1,261 @@
+package cdcexchangetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/cdcexchangetest"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+func createOrder(t *testing.T, client cdcexchange.CryptoDotComExchange, req cdcexchange.CreateOrderRequest) cdcexchange.Order {
+	ctx := context.Background()
+
+	res, err := client.CreateOrder(ctx, req)
+	require.NoError(t, err)
+
+	detail, err := client.GetOrderDetail(ctx, res.OrderID)
+	require.NoError(t, err)
+
+	return detail.OrderInfo
+}
+
+func TestServer_Matching_Limit(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("1000")))
+
+	s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("60"))
+	s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.6"), d("100"))
+
+	// the order takes the liquidity at 0.5 & the remainder rests on the book.
+	order := createOrder(t, client, limitOrder(cdcexchange.OrderSideBuy, "0.55", "100"))
+	assert.Equal(t, cdcexchange.OrderStatusActive, order.Status)
+	assert.Equal(t, d("60"), order.CumulativeQuantity)
+	assert.Equal(t, d("0.5"), order.AvgPrice)
+	assert.Equal(t, "CRO", order.FeeCurrency)
+
+	book, err := client.GetBook(ctx, "CRO_USDT", 0)
+	require.NoError(t, err)
+	assert.Equal(t, [][]decimal.Decimal{{d("0.55"), d("40"), d("1")}}, book.Bids)
+	assert.Equal(t, [][]decimal.Decimal{{d("0.6"), d("100"), d("1")}}, book.Asks)
+
+	// the resting remainder is taken by a sell.
+	s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.55"), d("50"))
+
+	detail, err := client.GetOrderDetail(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, cdcexchange.OrderStatusFilled, detail.OrderInfo.Status)
+	assert.Equal(t, d("100"), detail.OrderInfo.CumulativeQuantity)
+	assert.Equal(t, d("52"), detail.OrderInfo.CumulativeValue)
+	assert.Equal(t, d("0.52"), detail.OrderInfo.AvgPrice)
+
+	trades, err := client.GetTrades(ctx, cdcexchange.GetTradesRequest{InstrumentName: "CRO_USDT"})
+	require.NoError(t, err)
+	require.Len(t, trades.TradeList, 2)
+
+	maker, taker := trades.TradeList[0], trades.TradeList[1]
+	assert.Equal(t, cdcexchange.LiquidityIndicatorMaker, maker.LiquidityIndicator)
+	assert.Equal(t, d("0.55"), maker.TradedPrice)
+	assert.Equal(t, d("40"), maker.TradedQuantity)
+	assert.Equal(t, d("0.016"), maker.Fee)
+	assert.Equal(t, "CRO", maker.FeeCurrency)
+	assert.Equal(t, order.OrderID, maker.OrderID)
+
+	assert.Equal(t, cdcexchange.LiquidityIndicatorTaker, taker.LiquidityIndicator)
+	assert.Equal(t, d("0.5"), taker.TradedPrice)
+	assert.Equal(t, d("60"), taker.TradedQuantity)
+	assert.Equal(t, d("0.06"), taker.Fee)
+
+	// the balance held for the order at 0.55 which was bought at 0.5 is released once the order is filled.
+	assert.Equal(t, cdcexchange.Account{Balance: d("948"), Available: d("948"), Currency: "USDT"}, s.Account("USDT"))
+	assert.Equal(t, cdcexchange.Account{Balance: d("99.924"), Available: d("99.924"), Currency: "CRO"}, s.Account("CRO"))
+
+	publicTrades, err := client.GetPublicTrades(ctx, "CRO_USDT")
+	require.NoError(t, err)
+	require.Len(t, publicTrades, 2)
+	assert.Equal(t, cdcexchange.OrderSideSell, publicTrades[0].Side)
+	assert.Equal(t, d("0.55"), publicTrades[0].Price)
+}
+
+func TestServer_Matching_Market(t *testing.T) {
+	t.Run("buys notional amount", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("100")), cdcexchangetest.WithFees(d("0"), d("0")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("10"))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.7"), d("100"))
+
+		order := createOrder(t, client, cdcexchange.CreateOrderRequest{
+			InstrumentName: "CRO_USDT",
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeMarket,
+			Notional:       d("20"),
+		})
+
+		// 10 at 0.5 & 21.4 at 0.7, the remaining 0.02 cannot buy 0.1 at 0.7.
+		assert.Equal(t, cdcexchange.OrderStatusFilled, order.Status)
+		assert.Equal(t, d("31.4"), order.CumulativeQuantity)
+		assert.Equal(t, d("19.98"), order.CumulativeValue)
+		assert.Equal(t, d("80.02"), s.Account("USDT").Available)
+		assert.Equal(t, d("31.4"), s.Account("CRO").Available)
+	})
+
+	t.Run("cancels remainder without liquidity", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("CRO", d("100")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.5"), d("40"))
+
+		order := createOrder(t, client, cdcexchange.CreateOrderRequest{
+			InstrumentName: "CRO_USDT",
+			Side:           cdcexchange.OrderSideSell,
+			Type:           cdcexchange.OrderTypeMarket,
+			Quantity:       d("100"),
+		})
+
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, order.Status)
+		assert.Equal(t, d("40"), order.CumulativeQuantity)
+		assert.Equal(t, cdcexchange.Account{Balance: d("60"), Available: d("60"), Currency: "CRO"}, s.Account("CRO"))
+		assert.Equal(t, d("19.98"), s.Account("USDT").Available)
+	})
+
+	t.Run("stops once balance is spent", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("30")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("50"))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.6"), d("50"))
+
+		order := createOrder(t, client, cdcexchange.CreateOrderRequest{
+			InstrumentName: "CRO_USDT",
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeMarket,
+			Quantity:       d("100"),
+		})
+
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, order.Status)
+		assert.Equal(t, d("50"), order.CumulativeQuantity)
+		assert.Equal(t, cdcexchange.Account{Balance: d("5"), Available: d("5"), Currency: "USDT"}, s.Account("USDT"))
+	})
+}
+
+func TestServer_Matching_TimeInForce(t *testing.T) {
+	t.Run("fill or kill is cancelled without enough liquidity", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("100")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("50"))
+
+		req := limitOrder(cdcexchange.OrderSideBuy, "0.5", "100")
+		req.TimeInForce = cdcexchange.TimeInForceFillOrKill
+		order := createOrder(t, client, req)
+
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, order.Status)
+		assert.True(t, order.CumulativeQuantity.IsZero())
+		assert.Equal(t, d("100"), s.Account("USDT").Available)
+
+		req.Quantity = d("50")
+		order = createOrder(t, client, req)
+		assert.Equal(t, cdcexchange.OrderStatusFilled, order.Status)
+	})
+
+	t.Run("immediate or cancel cancels remainder", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("100")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("50"))
+
+		req := limitOrder(cdcexchange.OrderSideBuy, "0.5", "100")
+		req.TimeInForce = cdcexchange.TimeInForceImmediateOrCancel
+		order := createOrder(t, client, req)
+
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, order.Status)
+		assert.Equal(t, d("50"), order.CumulativeQuantity)
+		assert.Equal(t, cdcexchange.Account{Balance: d("75"), Available: d("75"), Currency: "USDT"}, s.Account("USDT"))
+	})
+
+	t.Run("post only is rejected if it would take liquidity", func(t *testing.T) {
+		s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("100")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.5"), d("50"))
+
+		req := limitOrder(cdcexchange.OrderSideBuy, "0.5", "100")
+		req.ExecInst = cdcexchange.ExecInstPostOnly
+		order := createOrder(t, client, req)
+
+		assert.Equal(t, cdcexchange.OrderStatusRejected, order.Status)
+		assert.Equal(t, "POST_ONLY_REJ", order.Reason)
+		assert.Equal(t, d("100"), s.Account("USDT").Available)
+
+		req.Price = d("0.49")
+		order = createOrder(t, client, req)
+		assert.Equal(t, cdcexchange.OrderStatusActive, order.Status)
+	})
+}
+
+func TestServer_Matching_Trigger(t *testing.T) {
+	t.Run("stop loss is executed as market order", func(t *testing.T) {
+		ctx := context.Background()
+		s, client := newServer(t, cdcexchangetest.WithBalance("CRO", d("100")))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.43"), d("100"))
+
+		order := createOrder(t, client, cdcexchange.CreateOrderRequest{
+			InstrumentName: "CRO_USDT",
+			Side:           cdcexchange.OrderSideSell,
+			Type:           cdcexchange.OrderTypeStopLoss,
+			Quantity:       d("100"),
+			TriggerPrice:   d("0.45"),
+		})
+		assert.Equal(t, cdcexchange.OrderStatusActive, order.Status)
+
+		// trades above the trigger price do not trigger the order.
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.46"), d("10"))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.46"), d("10"))
+
+		detail, err := client.GetOrderDetail(ctx, order.OrderID)
+		require.NoError(t, err)
+		assert.Equal(t, cdcexchange.OrderStatusActive, detail.OrderInfo.Status)
+
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.45"), d("10"))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.45"), d("10"))
+
+		detail, err = client.GetOrderDetail(ctx, order.OrderID)
+		require.NoError(t, err)
+		assert.Equal(t, cdcexchange.OrderStatusFilled, detail.OrderInfo.Status)
+		require.Len(t, detail.TradeList, 1)
+		assert.Equal(t, d("0.43"), detail.TradeList[0].TradedPrice)
+		assert.Equal(t, cdcexchange.LiquidityIndicatorTaker, detail.TradeList[0].LiquidityIndicator)
+		assert.Equal(t, d("0.043"), detail.TradeList[0].Fee)
+		assert.Equal(t, "USDT", detail.TradeList[0].FeeCurrency)
+
+		assert.True(t, s.Account("CRO").Balance.IsZero())
+		assert.Equal(t, d("42.957"), s.Account("USDT").Available)
+	})
+
+	t.Run("take profit limit rests once triggered", func(t *testing.T) {
+		ctx := context.Background()
+		s, client := newServer(t, cdcexchangetest.WithBalance("CRO", d("100")))
+
+		order := createOrder(t, client, cdcexchange.CreateOrderRequest{
+			InstrumentName: "CRO_USDT",
+			Side:           cdcexchange.OrderSideSell,
+			Type:           cdcexchange.OrderTypeTakeProfitLimit,
+			Price:          d("0.6"),
+			Quantity:       d("100"),
+			TriggerPrice:   d("0.55"),
+		})
+
+		// the order is not on the book until it is triggered.
+		book, err := client.GetBook(ctx, "CRO_USDT", 0)
+		require.NoError(t, err)
+		assert.Empty(t, book.Asks)
+
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d("0.55"), d("10"))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.55"), d("10"))
+
+		book, err = client.GetBook(ctx, "CRO_USDT", 0)
+		require.NoError(t, err)
+		assert.Equal(t, [][]decimal.Decimal{{d("0.6"), d("100"), d("1")}}, book.Asks)
+
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d("0.6"), d("100"))
+
+		detail, err := client.GetOrderDetail(ctx, order.OrderID)
+		require.NoError(t, err)
+		assert.Equal(t, cdcexchange.OrderStatusFilled, detail.OrderInfo.Status)
+		assert.Equal(t, cdcexchange.LiquidityIndicatorMaker, detail.TradeList[0].LiquidityIndicator)
+		assert.Equal(t, d("59.976"), s.Account("USDT").Available)
+	})
+}
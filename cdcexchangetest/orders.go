@@ -0,0 +1,406 @@
+package cdcexchangetest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+// defaultPageSize is the page size used if a page size is not specified.
+const defaultPageSize = 20
+
+// order is an order held by the server.
+type order struct {
+	cdcexchange.Order
+	// notional is the amount to spend of an order for a notional amount rather than a quantity.
+	notional decimal.Decimal
+	// locked is the amount of the balance of lockedCurrency held by the order while it is active.
+	locked         decimal.Decimal
+	lockedCurrency string
+	// external orders are placed by AddLiquidity on behalf of other participants, rather than by the account.
+	external bool
+	// wallet is the wallet of the account the order was created in (SPOT or MARGIN), empty for external orders.
+	wallet string
+	// pending orders are waiting to be triggered.
+	pending bool
+}
+
+// resting reports whether the order is resting on the book.
+func (o *order) resting() bool {
+	return o.Status == cdcexchange.OrderStatusActive && !o.pending && !o.Price.IsZero()
+}
+
+// remaining returns the quantity of the order which has not been filled.
+func (o *order) remaining() decimal.Decimal {
+	return o.Quantity.Sub(o.CumulativeQuantity)
+}
+
+func (s *Server) getAccountSummary(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	currency := stringParam(params, "currency")
+
+	accounts := make([]cdcexchange.Account, 0, len(s.wallets[walletSpot]))
+	for _, account := range s.wallets[walletSpot] {
+		if currency == "" || account.Currency == currency {
+			accounts = append(accounts, *account)
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Currency < accounts[j].Currency
+	})
+
+	return cdcexchange.AccountSummaryResult{Accounts: accounts}, 0
+}
+
+// createOrder validates an order, holds the balance it needs & executes it.
+func (s *Server) createOrder(wallet string, params map[string]interface{}) (interface{}, int64) {
+	instrument, ok := s.instrument(stringParam(params, "instrument_name"))
+	if !ok {
+		return nil, codeNoSymbol
+	}
+
+	side := cdcexchange.OrderSide(stringParam(params, "side"))
+	if side != cdcexchange.OrderSideBuy && side != cdcexchange.OrderSideSell {
+		return nil, codeBadSide
+	}
+
+	orderType := cdcexchange.OrderType(stringParam(params, "type"))
+	switch orderType {
+	case cdcexchange.OrderTypeLimit,
+		cdcexchange.OrderTypeMarket,
+		cdcexchange.OrderTypeStopLoss,
+		cdcexchange.OrderTypeStopLimit,
+		cdcexchange.OrderTypeTakeProfit,
+		cdcexchange.OrderTypeTakeProfitLimit:
+	default:
+		return nil, codeBadType
+	}
+
+	price, priceOK := decimalParam(params, "price")
+	quantity, quantityOK := decimalParam(params, "quantity")
+	notional, notionalOK := decimalParam(params, "notional")
+	triggerPrice, triggerPriceOK := decimalParam(params, "trigger_price")
+	if !priceOK || !quantityOK || !notionalOK || !triggerPriceOK {
+		return nil, codeBadRequest
+	}
+
+	if !hasMandatoryParams(orderType, side, price, quantity, notional, triggerPrice) {
+		return nil, codeMissingArg
+	}
+	if price.Scale() > int32(instrument.PriceDecimals) || triggerPrice.Scale() > int32(instrument.PriceDecimals) {
+		return nil, codePricePrecision
+	}
+	if quantity.Scale() > int32(instrument.QuantityDecimals) {
+		return nil, codeQtyPrecision
+	}
+
+	clientOID := stringParam(params, "client_oid")
+	if clientOID != "" {
+		for _, o := range s.orders {
+			if o.wallet == wallet && o.ClientOID == clientOID && o.Status == cdcexchange.OrderStatusActive {
+				return nil, codeDuplicate
+			}
+		}
+	}
+
+	// buy orders hold the quote currency they may spend, sell orders hold the base currency they may sell.
+	o := &order{wallet: wallet, notional: notional, lockedCurrency: instrument.BaseCurrency, locked: quantity}
+	feeCurrency := instrument.QuoteCurrency
+	if side == cdcexchange.OrderSideBuy {
+		feeCurrency = instrument.BaseCurrency
+		o.lockedCurrency = instrument.QuoteCurrency
+		o.locked = notional
+		if o.locked.IsZero() {
+			o.locked = price.Mul(quantity)
+		}
+	}
+
+	account := s.account(wallet, o.lockedCurrency)
+	if o.locked.GreaterThan(account.Available) {
+		return nil, codeNoBalance
+	}
+	account.Available = account.Available.Sub(o.locked)
+	account.Order = account.Order.Add(o.locked)
+
+	s.lastID++
+	now := cdctime.Time(s.clock.Now())
+	o.Order = cdcexchange.Order{
+		Status:         cdcexchange.OrderStatusActive,
+		Side:           side,
+		Price:          price,
+		Quantity:       quantity,
+		OrderID:        strconv.FormatInt(s.lastID, 10),
+		ClientOID:      clientOID,
+		CreateTime:     now,
+		UpdateTime:     now,
+		OrderType:      orderType,
+		InstrumentName: instrument.InstrumentName,
+		FeeCurrency:    feeCurrency,
+		TimeInForce:    cdcexchange.TimeInForce(stringParam(params, "time_in_force")),
+		ExecInst:       cdcexchange.ExecInst(stringParam(params, "exec_inst")),
+		TriggerPrice:   triggerPrice,
+	}
+	s.orders = append(s.orders, o)
+
+	s.execute(o)
+
+	return cdcexchange.CreateOrderResult{
+		OrderID:   o.OrderID,
+		ClientOID: o.ClientOID,
+	}, 0
+}
+
+func (s *Server) cancelOrder(wallet string, params map[string]interface{}) (interface{}, int64) {
+	o, ok := s.order(wallet, stringParam(params, "order_id"))
+	if !ok || o.InstrumentName != stringParam(params, "instrument_name") || o.Status != cdcexchange.OrderStatusActive {
+		return nil, codeBadRequest
+	}
+
+	s.closeOrder(o, cdcexchange.OrderStatusCancelled)
+
+	return nil, 0
+}
+
+func (s *Server) cancelAllOrders(wallet string, params map[string]interface{}) (interface{}, int64) {
+	instrument, ok := s.instrument(stringParam(params, "instrument_name"))
+	if !ok {
+		return nil, codeNoSymbol
+	}
+
+	for _, o := range s.orders {
+		if o.wallet == wallet && o.InstrumentName == instrument.InstrumentName && o.Status == cdcexchange.OrderStatusActive {
+			s.closeOrder(o, cdcexchange.OrderStatusCancelled)
+		}
+	}
+
+	return nil, 0
+}
+
+// getOrderHistory returns the orders which are no longer active, most recent first.
+func (s *Server) getOrderHistory(wallet string, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	var orders []cdcexchange.Order
+	for i := len(s.orders) - 1; i >= 0; i-- {
+		o := s.orders[i]
+		if o.wallet == wallet && o.Status != cdcexchange.OrderStatusActive && filter.match(o.InstrumentName, o.CreateTime) {
+			orders = append(orders, o.Order)
+		}
+	}
+
+	return cdcexchange.GetOrderHistoryResult{OrderList: page(filter, orders)}, 0
+}
+
+// getOpenOrders returns the active orders, most recent first.
+func (s *Server) getOpenOrders(wallet string, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	var orders []cdcexchange.Order
+	for i := len(s.orders) - 1; i >= 0; i-- {
+		o := s.orders[i]
+		if o.wallet == wallet && o.Status == cdcexchange.OrderStatusActive && filter.match(o.InstrumentName, o.CreateTime) {
+			orders = append(orders, o.Order)
+		}
+	}
+
+	return cdcexchange.GetOpenOrdersResult{
+		Count:     len(orders),
+		OrderList: page(filter, orders),
+	}, 0
+}
+
+func (s *Server) getOrderDetail(wallet string, params map[string]interface{}) (interface{}, int64) {
+	o, ok := s.order(wallet, stringParam(params, "order_id"))
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	trades := make([]cdcexchange.Trade, 0)
+	for _, trade := range s.trades[wallet] {
+		if trade.OrderID == o.OrderID {
+			trades = append(trades, trade)
+		}
+	}
+
+	return cdcexchange.GetOrderDetailResult{
+		TradeList: trades,
+		OrderInfo: o.Order,
+	}, 0
+}
+
+// getTrades returns the executed trades, most recent first.
+func (s *Server) getTrades(wallet string, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	var trades []cdcexchange.Trade
+	for i := len(s.trades[wallet]) - 1; i >= 0; i-- {
+		if trade := s.trades[wallet][i]; filter.match(trade.InstrumentName, trade.CreateTime) {
+			trades = append(trades, trade)
+		}
+	}
+
+	return cdcexchange.GetTradesResult{TradeList: page(filter, trades)}, 0
+}
+
+// order looks up an order of the account created in a wallet by ID.
+func (s *Server) order(wallet, orderID string) (*order, bool) {
+	for _, o := range s.orders {
+		if o.wallet == wallet && o.OrderID == orderID {
+			return o, true
+		}
+	}
+
+	return nil, false
+}
+
+// closeOrder sets the final status of an order, releasing the balance it held.
+func (s *Server) closeOrder(o *order, status cdcexchange.OrderStatus) {
+	o.Status = status
+	o.UpdateTime = cdctime.Time(s.clock.Now())
+
+	if o.external {
+		return
+	}
+
+	account := s.account(o.wallet, o.lockedCurrency)
+	account.Available = account.Available.Add(o.locked)
+	account.Order = account.Order.Sub(o.locked)
+	o.locked = decimal.Zero
+}
+
+// account returns the balance of a currency in a wallet, creating an empty balance if the currency has not been held.
+func (s *Server) account(wallet, currency string) *cdcexchange.Account {
+	accounts, ok := s.wallets[wallet]
+	if !ok {
+		accounts = make(map[string]*cdcexchange.Account)
+		s.wallets[wallet] = accounts
+	}
+
+	account, ok := accounts[currency]
+	if !ok {
+		account = &cdcexchange.Account{Currency: currency}
+		accounts[currency] = account
+	}
+
+	return account
+}
+
+// hasMandatoryParams reports whether the mandatory params of the order type & side are set.
+func hasMandatoryParams(orderType cdcexchange.OrderType, side cdcexchange.OrderSide, price, quantity, notional, triggerPrice decimal.Decimal) bool {
+	switch orderType {
+	case cdcexchange.OrderTypeLimit:
+		return !price.IsZero() && !quantity.IsZero()
+	case cdcexchange.OrderTypeStopLimit, cdcexchange.OrderTypeTakeProfitLimit:
+		return !price.IsZero() && !quantity.IsZero() && !triggerPrice.IsZero()
+	case cdcexchange.OrderTypeMarket:
+		if side == cdcexchange.OrderSideBuy {
+			return !notional.IsZero() || !quantity.IsZero()
+		}
+		return !quantity.IsZero()
+	default:
+		if side == cdcexchange.OrderSideBuy {
+			return !notional.IsZero() && !triggerPrice.IsZero()
+		}
+		return !quantity.IsZero() && !triggerPrice.IsZero()
+	}
+}
+
+// filter is the instrument or currency, time range & page of a request for orders, trades or other records.
+type filter struct {
+	instrumentName string
+	currency       string
+	start          time.Time
+	end            time.Time
+	pageSize       int
+	page           int
+}
+
+// newFilter reads the filter of a request from its params.
+func newFilter(params map[string]interface{}) (filter, bool) {
+	start, startOK := intParam(params, "start_ts")
+	end, endOK := intParam(params, "end_ts")
+	pageSize, pageSizeOK := intParam(params, "page_size")
+	page, pageOK := intParam(params, "page")
+	if !startOK || !endOK || !pageSizeOK || !pageOK || pageSize < 0 || page < 0 {
+		return filter{}, false
+	}
+
+	f := filter{
+		instrumentName: stringParam(params, "instrument_name"),
+		currency:       stringParam(params, "currency"),
+		pageSize:       int(pageSize),
+		page:           int(page),
+	}
+	if f.pageSize == 0 {
+		f.pageSize = defaultPageSize
+	}
+	if start != 0 {
+		f.start = time.UnixMilli(start)
+	}
+	if end != 0 {
+		f.end = time.UnixMilli(end)
+	}
+
+	return f, true
+}
+
+// match reports whether an order or trade of an instrument created at the specified time matches the filter.
+func (f filter) match(instrumentName string, createTime cdctime.Time) bool {
+	if f.instrumentName != "" && f.instrumentName != instrumentName {
+		return false
+	}
+
+	return f.matchTime(createTime)
+}
+
+// matchCurrency reports whether a record of a currency at the specified time matches the filter.
+func (f filter) matchCurrency(currency string, t cdctime.Time) bool {
+	if f.currency != "" && f.currency != currency {
+		return false
+	}
+
+	return f.matchTime(t)
+}
+
+// matchTime reports whether the specified time is within the time range of the filter.
+func (f filter) matchTime(t cdctime.Time) bool {
+	if !f.start.IsZero() && t.Time().Before(f.start) {
+		return false
+	}
+	return f.end.IsZero() || !t.Time().After(f.end)
+}
+
+// page returns the requested page of items.
+func page[T any](f filter, items []T) []T {
+	start, end := f.bounds(len(items))
+	return append(make([]T, 0), items[start:end]...)
+}
+
+// bounds returns the bounds of the requested page of n items.
+func (f filter) bounds(n int) (int, int) {
+	start := f.page * f.pageSize
+	if start > n {
+		start = n
+	}
+
+	end := start + f.pageSize
+	if end > n {
+		end = n
+	}
+
+	return start, end
+}
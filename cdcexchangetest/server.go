@@ -0,0 +1,404 @@
+// Package cdcexchangetest provides an in-process fake of the Crypto.com Exchange v2 REST API,
+// for integration testing code which uses the cdcexchange client without calling the Exchange.
+//
+//	s := cdcexchangetest.NewServer("some api key", "some secret key",
+//		cdcexchangetest.WithBalance("USDT", decimal.MustParse("1000")),
+//	)
+//	defer s.Close()
+//
+//	client, err := cdcexchange.New("some api key", "some secret key",
+//		cdcexchange.WithBaseURL(s.URL),
+//	)
+package cdcexchangetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+)
+
+const (
+	// basePath is the path of the v2 API, the path of each request is the method following basePath.
+	basePath = "/v2/"
+
+	// maxNonceDifference is the maximum difference between the nonce of a request & the server's clock.
+	maxNonceDifference = 30 * time.Second
+
+	codeSystemError    int64 = 10001
+	codeUnauthorized   int64 = 10002
+	codeBadRequest     int64 = 10004
+	codeInvalidNonce   int64 = 10007
+	codeMethodNotFound int64 = 10008
+	codeDuplicate      int64 = 20001
+	codeNoBalance      int64 = 20002
+	codeNoSymbol       int64 = 30003
+	codeBadSide        int64 = 30004
+	codeBadType        int64 = 30005
+	codeMissingArg     int64 = 30010
+	codePricePrecision int64 = 30013
+	codeQtyPrecision   int64 = 30014
+	codeMaxAmount      int64 = 30024
+	codeActiveLoan     int64 = 40002
+	codeNoActiveLoan   int64 = 40005
+
+	walletSpot        = "SPOT"
+	walletMargin      = "MARGIN"
+	walletDerivatives = "DERIVATIVES"
+)
+
+// MasterAccountUUID is the UUID of the master account, whose SPOT wallet funds are transferred to & from
+// by private/subaccount/transfer.
+const MasterAccountUUID = "00000000-0000-0000-0000-000000000000"
+
+type (
+	// Server is a fake Exchange which serves the v2 REST API over an httptest.Server.
+	//
+	// Private requests are authenticated with the API key & secret key of the server, using the same
+	// signature algorithm as the Exchange. Orders & balances are held in memory for the lifetime of the server,
+	// orders are executed by a matching engine against liquidity added with AddLiquidity, so the server can be
+	// used for paper trading.
+	//
+	// The account has a SPOT, MARGIN & DERIVATIVES wallet, funds are moved between them with private/margin/transfer
+	// & private/deriv/transfer. Margin orders are held in & settled against the MARGIN wallet, which can borrow
+	// the currencies of instruments with margin trading enabled. Sub-accounts added with WithSubAccounts each have
+	// their own balances, funds are moved between them & the SPOT wallet of the master account (MasterAccountUUID)
+	// with private/subaccount/transfer. Deposits are made to the SPOT wallet with Deposit.
+	//
+	// Supported methods:
+	//  - public/get-instruments
+	//  - public/get-book
+	//  - public/get-ticker
+	//  - public/get-trades
+	//  - public/get-candlestick
+	//  - public/margin/get-loan-currencies
+	//  - public/margin/get-transfer-currencies
+	//  - private/get-account-summary
+	//  - private/create-order
+	//  - private/cancel-order
+	//  - private/cancel-all-orders
+	//  - private/get-order-history
+	//  - private/get-open-orders
+	//  - private/get-order-detail
+	//  - private/get-trades
+	//  - private/margin/transfer
+	//  - private/margin/borrow
+	//  - private/margin/repay
+	//  - private/margin/get-user-config
+	//  - private/margin/get-account-summary
+	//  - private/margin/get-transfer-history
+	//  - private/margin/get-borrow-history
+	//  - private/margin/get-interest-history
+	//  - private/margin/get-repay-history
+	//  - private/margin/get-liquidation-history
+	//  - private/margin/get-liquidation-orders
+	//  - private/margin/create-order
+	//  - private/margin/cancel-order
+	//  - private/margin/cancel-all-orders
+	//  - private/margin/get-order-history
+	//  - private/margin/get-open-orders
+	//  - private/margin/get-order-detail
+	//  - private/margin/get-trades
+	//  - private/deriv/transfer
+	//  - private/deriv/get-transfer-history
+	//  - private/subaccount/get-sub-accounts
+	//  - private/subaccount/transfer
+	//  - private/subaccount/get-transfer-history
+	//  - private/get-deposit-address
+	//  - private/get-deposit-history
+	//  - private/create-withdrawal
+	//  - private/get-withdrawal-history
+	//
+	// Any other method is rejected with errors.ErrMethodNotFound (10008).
+	Server struct {
+		// URL is the base URL of the server (e.g. http://127.0.0.1:1234/v2/).
+		URL string
+
+		server    *httptest.Server
+		apiKey    string
+		secretKey string
+		clock     clockwork.Clock
+		handlers  map[string]handler
+		makerFee  decimal.Decimal
+		takerFee  decimal.Decimal
+
+		mu          sync.Mutex
+		instruments []cdcexchange.Instrument
+		// wallets are the balances of each wallet of the account & of each sub-account, keyed by wallet then currency.
+		// Sub-accounts are keyed by their UUID.
+		wallets map[string]map[string]*cdcexchange.Account
+		orders  []*order
+		// trades are the trades of the account, keyed by the wallet of their order.
+		trades       map[string][]cdcexchange.Trade
+		marketTrades []cdcexchange.MarketTrade
+		// borrowed is the amount of each currency borrowed by the MARGIN wallet.
+		borrowed        map[string]decimal.Decimal
+		borrows         []cdcexchange.MarginBorrowRecord
+		repays          []cdcexchange.MarginRepayRecord
+		walletTransfers []walletTransfer
+		subAccounts     []cdcexchange.SubAccount
+		subTransfers    []subAccountTransfer
+		deposits        []cdcexchange.Deposit
+		withdrawals     []cdcexchange.Withdrawal
+		errs            map[string][]int64
+		lastID          int64
+	}
+
+	// Option configures a Server.
+	Option func(*Server)
+
+	// handler handles a request to a method, returning the result or the code of the error to respond with.
+	// s.mu is held while the handler is called.
+	handler struct {
+		private bool
+		handle  func(s *Server, r *http.Request, params map[string]interface{}) (interface{}, int64)
+	}
+
+	// response is the body of each response.
+	response struct {
+		ID     int64       `json:"id"`
+		Method string      `json:"method"`
+		Code   int64       `json:"code"`
+		Result interface{} `json:"result,omitempty"`
+	}
+
+	// transport sends every request to the server, whichever host it was addressed to.
+	transport struct {
+		host string
+		base http.RoundTripper
+	}
+)
+
+// defaultInstruments are the instruments listed by the server if WithInstruments is not used.
+var defaultInstruments = []cdcexchange.Instrument{
+	{InstrumentName: "BTC_USDT", BaseCurrency: "BTC", QuoteCurrency: "USDT", PriceDecimals: 2, QuantityDecimals: 6, MarginTradingEnabled: true},
+	{InstrumentName: "CRO_USDT", BaseCurrency: "CRO", QuoteCurrency: "USDT", PriceDecimals: 5, QuantityDecimals: 1, MarginTradingEnabled: true},
+	{InstrumentName: "ETH_USDT", BaseCurrency: "ETH", QuoteCurrency: "USDT", PriceDecimals: 2, QuantityDecimals: 4, MarginTradingEnabled: true},
+}
+
+// WithInstruments sets the instruments listed by the server (Default: BTC_USDT, CRO_USDT & ETH_USDT).
+func WithInstruments(instruments ...cdcexchange.Instrument) Option {
+	return func(s *Server) {
+		s.instruments = instruments
+	}
+}
+
+// WithBalance sets the available balance of a currency in the SPOT wallet when the server starts.
+func WithBalance(currency string, amount decimal.Decimal) Option {
+	return func(s *Server) {
+		account := s.account(walletSpot, currency)
+		account.Balance = amount
+		account.Available = amount
+	}
+}
+
+// WithClock sets the clock used for timestamps & to check the nonce of requests (Default: the real clock).
+func WithClock(clock clockwork.Clock) Option {
+	return func(s *Server) {
+		s.clock = clock
+	}
+}
+
+// NewServer starts a fake Exchange which accepts requests signed with apiKey & secretKey.
+//
+// The server should be closed once it is no longer needed.
+func NewServer(apiKey, secretKey string, opts ...Option) *Server {
+	s := &Server{
+		apiKey:      apiKey,
+		secretKey:   secretKey,
+		clock:       clockwork.NewRealClock(),
+		handlers:    handlers(),
+		makerFee:    defaultMakerFee,
+		takerFee:    defaultTakerFee,
+		instruments: defaultInstruments,
+		wallets:     make(map[string]map[string]*cdcexchange.Account),
+		trades:      make(map[string][]cdcexchange.Trade),
+		borrowed:    make(map[string]decimal.Decimal),
+		errs:        make(map[string][]int64),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL + basePath
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an HTTP client which sends all requests to the server, whichever environment they are made against,
+// so that a cdcexchange client configured with WithHTTPClient calls the server instead of the Exchange.
+func (s *Server) Client() *http.Client {
+	u, _ := url.Parse(s.server.URL)
+
+	return &http.Client{
+		Transport: transport{
+			host: u.Host,
+			base: s.server.Client().Transport,
+		},
+	}
+}
+
+// InjectError makes the next request to method fail with the specified code from the errors package
+// (e.g. 10006 for errors.ErrTooManyRequests), instead of being handled.
+//
+// Errors injected for the same method are returned by consecutive requests, in the order they were injected.
+func (s *Server) InjectError(method string, code int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errs[method] = append(s.errs[method], code)
+}
+
+// Account returns the balance of a currency in the SPOT wallet.
+func (s *Server) Account(currency string) cdcexchange.Account {
+	return s.WalletAccount(walletSpot, currency)
+}
+
+// WalletAccount returns the balance of a currency in a wallet of the account (SPOT, MARGIN or DERIVATIVES),
+// or of the sub-account with the specified UUID.
+func (s *Server) WalletAccount(wallet, currency string) cdcexchange.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account, ok := s.wallets[wallet][currency]; ok {
+		return *account
+	}
+
+	return cdcexchange.Account{Currency: currency}
+}
+
+// ServeHTTP handles a request to the v2 API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, basePath)
+
+	var body api.Request
+	if r.Body != nil && r.ContentLength != 0 {
+		d := json.NewDecoder(r.Body)
+		// numbers are kept as they were sent, so that the signature can be verified.
+		d.UseNumber()
+		if err := d.Decode(&body); err != nil {
+			s.respond(w, method, body.ID, nil, codeBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if code, ok := s.injectedError(method); ok {
+		s.respond(w, method, body.ID, nil, code)
+		return
+	}
+
+	h, ok := s.handlers[method]
+	if !ok {
+		s.respond(w, method, body.ID, nil, codeMethodNotFound)
+		return
+	}
+
+	if h.private {
+		if code := s.authenticate(method, body); code != 0 {
+			s.respond(w, method, body.ID, nil, code)
+			return
+		}
+	}
+
+	result, code := h.handle(s, r, body.Params)
+	s.respond(w, method, body.ID, result, code)
+}
+
+// injectedError returns the next error injected for method, if any.
+func (s *Server) injectedError(method string) (int64, bool) {
+	codes := s.errs[method]
+	if len(codes) == 0 {
+		return 0, false
+	}
+
+	s.errs[method] = codes[1:]
+	return codes[0], true
+}
+
+// authenticate checks the API key, nonce & signature of a private request.
+func (s *Server) authenticate(method string, body api.Request) int64 {
+	if body.APIKey != s.apiKey || body.Method != method {
+		return codeUnauthorized
+	}
+
+	nonce := time.UnixMilli(body.Nonce)
+	if d := s.clock.Now().Sub(nonce); d > maxNonceDifference || d < -maxNonceDifference {
+		return codeInvalidNonce
+	}
+
+	signature, err := auth.Generator{}.GenerateSignature(auth.SignatureRequest{
+		APIKey:    body.APIKey,
+		SecretKey: s.secretKey,
+		ID:        body.ID,
+		Method:    body.Method,
+		Timestamp: body.Nonce,
+		Params:    body.Params,
+	})
+	if err != nil {
+		return codeSystemError
+	}
+	if signature != body.Signature {
+		return codeUnauthorized
+	}
+
+	return 0
+}
+
+// respond writes the response to a request, with the HTTP status code the Exchange uses for the response code.
+func (s *Server) respond(w http.ResponseWriter, method string, id int64, result interface{}, code int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode(code))
+
+	_ = json.NewEncoder(w).Encode(response{
+		ID:     id,
+		Method: method,
+		Code:   code,
+		Result: result,
+	})
+}
+
+// statusCode returns the HTTP status code of a response with the specified response code.
+func statusCode(code int64) int {
+	switch code {
+	case 0:
+		return http.StatusOK
+	case 10001, 100001:
+		return http.StatusInternalServerError
+	case 10002, 10003, 10005:
+		return http.StatusUnauthorized
+	case 10006:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// RoundTrip sends the request to the server.
+func (t transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.host
+	r.Host = t.host
+
+	return t.base.RoundTrip(r)
+}
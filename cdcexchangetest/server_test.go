@@ -0,0 +1,281 @@
+package cdcexchangetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/cdcexchangetest"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+const (
+	apiKey    = "some api key"
+	secretKey = "some secret key"
+)
+
+var d = decimal.MustParse
+
+func newServer(t *testing.T, opts ...cdcexchangetest.Option) (*cdcexchangetest.Server, cdcexchange.CryptoDotComExchange) {
+	s := cdcexchangetest.NewServer(apiKey, secretKey, opts...)
+	t.Cleanup(s.Close)
+
+	return s, newClient(t, s, secretKey)
+}
+
+func newClient(t *testing.T, s *cdcexchangetest.Server, secretKey string) cdcexchange.CryptoDotComExchange {
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithBaseURL(s.URL),
+		cdcexchange.WithRateLimitDisabled(),
+	)
+	require.NoError(t, err)
+
+	return client
+}
+
+func limitOrder(side cdcexchange.OrderSide, price, quantity string) cdcexchange.CreateOrderRequest {
+	return cdcexchange.CreateOrderRequest{
+		InstrumentName: "CRO_USDT",
+		Side:           side,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          d(price),
+		Quantity:       d(quantity),
+	}
+}
+
+func TestServer_Orders(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("1000")))
+
+	res, err := client.CreateOrder(ctx, limitOrder(cdcexchange.OrderSideBuy, "0.5", "100"))
+	require.NoError(t, err)
+	require.NotEmpty(t, res.OrderID)
+
+	// the balance needed by the order is held until it is closed.
+	assert.Equal(t, cdcexchange.Account{
+		Balance:   d("1000"),
+		Available: d("950"),
+		Order:     d("50"),
+		Currency:  "USDT",
+	}, s.Account("USDT"))
+
+	open, err := client.GetOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{InstrumentName: "CRO_USDT"})
+	require.NoError(t, err)
+	require.Equal(t, 1, open.Count)
+	assert.Equal(t, res.OrderID, open.OrderList[0].OrderID)
+	assert.Equal(t, cdcexchange.OrderStatusActive, open.OrderList[0].Status)
+	assert.Equal(t, d("0.5"), open.OrderList[0].Price)
+
+	book, err := client.GetBook(ctx, "CRO_USDT", 10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]decimal.Decimal{{d("0.5"), d("100"), d("1")}}, book.Bids)
+	assert.Empty(t, book.Asks)
+
+	tickers, err := client.GetTickers(ctx, "CRO_USDT")
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+	assert.Equal(t, d("0.5"), tickers[0].BidPrice)
+
+	require.NoError(t, client.CancelOrder(ctx, "CRO_USDT", res.OrderID))
+
+	open, err = client.GetOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, open.Count)
+
+	history, err := client.GetOrderHistory(ctx, cdcexchange.GetOrderHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, history.OrderList, 1)
+	assert.Equal(t, cdcexchange.OrderStatusCancelled, history.OrderList[0].Status)
+
+	detail, err := client.GetOrderDetail(ctx, res.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, cdcexchange.OrderStatusCancelled, detail.OrderInfo.Status)
+	assert.Empty(t, detail.TradeList)
+
+	accounts, err := client.GetAccountSummary(ctx, "USDT")
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, d("1000"), accounts[0].Available)
+	assert.True(t, accounts[0].Order.IsZero())
+}
+
+func TestServer_CancelAllOrders(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t,
+		cdcexchangetest.WithBalance("USDT", d("1000")),
+		cdcexchangetest.WithBalance("CRO", d("1000")),
+	)
+
+	for _, req := range []cdcexchange.CreateOrderRequest{
+		limitOrder(cdcexchange.OrderSideBuy, "0.5", "100"),
+		limitOrder(cdcexchange.OrderSideBuy, "0.5", "200"),
+		limitOrder(cdcexchange.OrderSideSell, "0.6", "300"),
+	} {
+		_, err := client.CreateOrder(ctx, req)
+		require.NoError(t, err)
+	}
+
+	book, err := client.GetBook(ctx, "CRO_USDT", 0)
+	require.NoError(t, err)
+	assert.Equal(t, [][]decimal.Decimal{{d("0.5"), d("300"), d("2")}}, book.Bids)
+	assert.Equal(t, [][]decimal.Decimal{{d("0.6"), d("300"), d("1")}}, book.Asks)
+	assert.Equal(t, d("700"), s.Account("CRO").Available)
+
+	require.NoError(t, client.CancelAllOrders(ctx, "CRO_USDT"))
+
+	open, err := client.GetOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, open.Count)
+	assert.Equal(t, d("1000"), s.Account("USDT").Available)
+	assert.Equal(t, d("1000"), s.Account("CRO").Available)
+}
+
+func TestServer_Candlesticks(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clockwork.NewFakeClockAt(start)
+	s, client := newServer(t, cdcexchangetest.WithClock(clock))
+
+	// trades of each minute are aggregated into one candlestick.
+	for _, trade := range []struct {
+		price, quantity string
+		at              time.Duration
+	}{
+		{price: "0.5", quantity: "10", at: 0},
+		{price: "0.7", quantity: "20", at: 10 * time.Second},
+		{price: "0.4", quantity: "30", at: 20 * time.Second},
+		{price: "0.6", quantity: "40", at: 80 * time.Second},
+	} {
+		clock.Advance(start.Add(trade.at).Sub(clock.Now()))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideSell, d(trade.price), d(trade.quantity))
+		s.AddLiquidity("CRO_USDT", cdcexchange.OrderSideBuy, d(trade.price), d(trade.quantity))
+	}
+
+	candlesticks, err := client.GetCandlesticks(context.Background(), "CRO_USDT", cdcexchange.Interval1Minute)
+	require.NoError(t, err)
+	require.Len(t, candlesticks, 2)
+
+	assert.Equal(t, start, candlesticks[0].Timestamp.Time().UTC())
+	assert.Equal(t, d("0.5"), candlesticks[0].Open)
+	assert.Equal(t, d("0.7"), candlesticks[0].High)
+	assert.Equal(t, d("0.4"), candlesticks[0].Low)
+	assert.Equal(t, d("0.4"), candlesticks[0].Close)
+	assert.Equal(t, d("60"), candlesticks[0].Volume)
+
+	assert.Equal(t, start.Add(time.Minute), candlesticks[1].Timestamp.Time().UTC())
+	assert.Equal(t, d("0.6"), candlesticks[1].Open)
+	assert.Equal(t, d("40"), candlesticks[1].Volume)
+
+	_, err = client.GetCandlesticks(context.Background(), "DOGE_USDT", cdcexchange.Interval1Minute)
+	assert.True(t, errors.Is(err, cdcerrors.ErrSymbolNotFound))
+}
+
+func TestServer_Client(t *testing.T) {
+	s := cdcexchangetest.NewServer(apiKey, secretKey)
+	t.Cleanup(s.Close)
+
+	// requests to the production environment are sent to the server.
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithProductionEnvironment(),
+		cdcexchange.WithHTTPClient(s.Client()),
+	)
+	require.NoError(t, err)
+
+	instrument, err := client.GetInstrument(context.Background(), "CRO_USDT")
+	require.NoError(t, err)
+	assert.Equal(t, 5, instrument.PriceDecimals)
+}
+
+func TestServer_InvalidNonce(t *testing.T) {
+	// the client re-syncs its clock with the server's clock & retries the request.
+	clock := clockwork.NewFakeClockAt(time.Now().Add(time.Hour))
+	_, client := newServer(t,
+		cdcexchangetest.WithClock(clock),
+		cdcexchangetest.WithBalance("USDT", d("1000")),
+	)
+
+	_, err := client.CreateOrder(context.Background(), limitOrder(cdcexchange.OrderSideBuy, "0.5", "100"))
+	require.NoError(t, err)
+}
+
+func TestServer_Error(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns error given an incorrect signature", func(t *testing.T) {
+		s, _ := newServer(t)
+		client := newClient(t, s, "some other secret key")
+
+		_, err := client.GetAccountSummary(ctx, "")
+		require.Error(t, err)
+
+		var responseError cdcerrors.ResponseError
+		require.True(t, errors.As(err, &responseError))
+		assert.Equal(t, int64(10002), responseError.Code)
+		assert.True(t, errors.Is(err, cdcerrors.ErrUnauthorized))
+	})
+
+	t.Run("returns injected error", func(t *testing.T) {
+		s, client := newServer(t)
+		s.InjectError("private/get-open-orders", 10003)
+
+		_, err := client.GetOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{})
+		assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+
+		// the error is only returned once.
+		_, err = client.GetOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error given insufficient balance", func(t *testing.T) {
+		_, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("49.99")))
+
+		_, err := client.CreateOrder(ctx, limitOrder(cdcexchange.OrderSideBuy, "0.5", "100"))
+		assert.True(t, errors.Is(err, cdcerrors.ErrNegativeBalance))
+	})
+
+	t.Run("returns error given unknown instrument", func(t *testing.T) {
+		_, client := newServer(t)
+
+		req := limitOrder(cdcexchange.OrderSideBuy, "0.5", "100")
+		req.InstrumentName = "DOGE_USDT"
+
+		_, err := client.CreateOrder(ctx, req)
+		assert.True(t, errors.Is(err, cdcerrors.ErrSymbolNotFound))
+	})
+
+	t.Run("returns error given too many decimal places", func(t *testing.T) {
+		_, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("1000")))
+
+		_, err := client.CreateOrder(ctx, limitOrder(cdcexchange.OrderSideBuy, "0.000001", "100"))
+		assert.True(t, errors.Is(err, cdcerrors.ErrInvalidPricePrecision))
+
+		_, err = client.CreateOrder(ctx, limitOrder(cdcexchange.OrderSideBuy, "0.5", "100.01"))
+		assert.True(t, errors.Is(err, cdcerrors.ErrInvalidQuantityPrecision))
+	})
+
+	t.Run("returns error given duplicate client order ID", func(t *testing.T) {
+		_, client := newServer(t, cdcexchangetest.WithBalance("USDT", d("1000")))
+
+		req := limitOrder(cdcexchange.OrderSideBuy, "0.5", "100")
+		req.ClientOID = "some client oid"
+
+		_, err := client.CreateOrder(ctx, req)
+		require.NoError(t, err)
+
+		_, err = client.CreateOrder(ctx, req)
+		assert.True(t, errors.Is(err, cdcerrors.ErrDuplicateRecord))
+	})
+
+	t.Run("returns error given order which is not open", func(t *testing.T) {
+		_, client := newServer(t)
+
+		err := client.CancelOrder(ctx, "CRO_USDT", "1")
+		assert.True(t, errors.Is(err, cdcerrors.ErrBadRequest))
+	})
+}
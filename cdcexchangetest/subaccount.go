@@ -0,0 +1,131 @@
+package cdcexchangetest
+
+import (
+	"net/http"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+// subAccountTransfer is a transfer between the master account & a sub-account, or between two sub-accounts.
+type subAccountTransfer struct {
+	from     string
+	to       string
+	currency string
+	amount   decimal.Decimal
+	time     cdctime.Time
+}
+
+// WithSubAccounts sets the sub-accounts of the account, each of which starts with no balance.
+// The MasterAccountUUID of a sub-account is set to MasterAccountUUID if it is empty.
+func WithSubAccounts(subAccounts ...cdcexchange.SubAccount) Option {
+	return func(s *Server) {
+		s.subAccounts = make([]cdcexchange.SubAccount, 0, len(subAccounts))
+		for _, subAccount := range subAccounts {
+			if subAccount.MasterAccountUUID == "" {
+				subAccount.MasterAccountUUID = MasterAccountUUID
+			}
+			s.subAccounts = append(s.subAccounts, subAccount)
+		}
+	}
+}
+
+func (s *Server) getSubAccounts(_ *http.Request, _ map[string]interface{}) (interface{}, int64) {
+	return cdcexchange.GetSubAccountsResult{
+		SubAccountList: append(make([]cdcexchange.SubAccount, 0), s.subAccounts...),
+	}, 0
+}
+
+// subAccountTransfer moves funds between the SPOT wallet of the master account & a sub-account,
+// or between two sub-accounts.
+func (s *Server) subAccountTransfer(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	var (
+		currency = stringParam(params, "currency")
+		from     = stringParam(params, "from")
+		to       = stringParam(params, "to")
+	)
+	amount, ok := decimalParam(params, "amount")
+	if !ok || currency == "" || amount.Sign() <= 0 || from == to {
+		return nil, codeBadRequest
+	}
+
+	fromWallet, fromOK := s.subAccountWallet(from)
+	toWallet, toOK := s.subAccountWallet(to)
+	if !fromOK || !toOK {
+		return nil, codeBadRequest
+	}
+
+	if !s.move(fromWallet, toWallet, currency, amount) {
+		return nil, codeNoBalance
+	}
+
+	s.subTransfers = append(s.subTransfers, subAccountTransfer{
+		from:     from,
+		to:       to,
+		currency: currency,
+		amount:   amount,
+		time:     cdctime.Time(s.clock.Now()),
+	})
+
+	return nil, 0
+}
+
+// getSubAccountTransferHistory returns the transfers in & out of the master account or a sub-account, most recent first.
+func (s *Server) getSubAccountTransferHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+
+	uuid := stringParam(params, "sub_account_uuid")
+	if uuid == "" {
+		uuid = MasterAccountUUID
+	}
+	if _, ok := s.subAccountWallet(uuid); !ok {
+		return nil, codeBadRequest
+	}
+	direction := cdcexchange.TransferDirection(stringParam(params, "direction"))
+
+	records := make([]cdcexchange.SubAccountTransferRecord, 0)
+	for i := len(s.subTransfers) - 1; i >= 0; i-- {
+		t := s.subTransfers[i]
+		if t.from != uuid && t.to != uuid || !filter.matchCurrency(t.currency, t.time) {
+			continue
+		}
+
+		record := cdcexchange.SubAccountTransferRecord{
+			Direction:   cdcexchange.TransferDirectionIn,
+			Time:        t.time,
+			Amount:      t.amount,
+			Status:      statusCompleted,
+			Information: "From " + t.from,
+			Currency:    t.currency,
+		}
+		if t.from == uuid {
+			record.Direction = cdcexchange.TransferDirectionOut
+			record.Information = "To " + t.to
+		}
+
+		if direction == "" || record.Direction == direction {
+			records = append(records, record)
+		}
+	}
+
+	return cdcexchange.GetSubAccountTransferHistoryResult{TransferList: page(filter, records)}, 0
+}
+
+// subAccountWallet returns the wallet holding the balances of the master account or a sub-account.
+func (s *Server) subAccountWallet(uuid string) (string, bool) {
+	if uuid == MasterAccountUUID {
+		return walletSpot, true
+	}
+
+	for _, subAccount := range s.subAccounts {
+		if subAccount.UUID == uuid {
+			return uuid, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,71 @@
+package cdcexchangetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/cdcexchangetest"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+func TestServer_SubAccounts(t *testing.T) {
+	const subAccountUUID = "some sub account uuid"
+
+	ctx := context.Background()
+	s, client := newServer(t,
+		cdcexchangetest.WithBalance("USDT", d("100")),
+		cdcexchangetest.WithSubAccounts(cdcexchange.SubAccount{UUID: subAccountUUID, Label: "some label"}),
+	)
+
+	subAccounts, err := client.GetSubAccounts(ctx)
+	require.NoError(t, err)
+	require.Len(t, subAccounts, 1)
+	assert.Equal(t, subAccountUUID, subAccounts[0].UUID)
+	assert.Equal(t, cdcexchangetest.MasterAccountUUID, subAccounts[0].MasterAccountUUID)
+
+	err = client.SubAccountTransfer(ctx, cdcexchange.SubAccountTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchangetest.MasterAccountUUID,
+		To:       "some unknown uuid",
+		Amount:   d("10"),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrBadRequest))
+
+	err = client.SubAccountTransfer(ctx, cdcexchange.SubAccountTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchangetest.MasterAccountUUID,
+		To:       subAccountUUID,
+		Amount:   d("30"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, d("70"), s.Account("USDT").Available)
+	assert.Equal(t, d("30"), s.WalletAccount(subAccountUUID, "USDT").Available)
+
+	err = client.SubAccountTransfer(ctx, cdcexchange.SubAccountTransferRequest{
+		Currency: "USDT",
+		From:     subAccountUUID,
+		To:       cdcexchangetest.MasterAccountUUID,
+		Amount:   d("50"),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrNegativeBalance))
+
+	transfers, err := client.GetSubAccountTransferHistory(ctx, cdcexchange.GetSubAccountTransferHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, transfers.TransferList, 1)
+	assert.Equal(t, cdcexchange.TransferDirectionOut, transfers.TransferList[0].Direction)
+	assert.Equal(t, d("30"), transfers.TransferList[0].Amount)
+
+	transfers, err = client.GetSubAccountTransferHistory(ctx, cdcexchange.GetSubAccountTransferHistoryRequest{
+		SubAccountUUID: subAccountUUID,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers.TransferList, 1)
+	assert.Equal(t, cdcexchange.TransferDirectionIn, transfers.TransferList[0].Direction)
+}
@@ -0,0 +1,150 @@
+package cdcexchangetest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+// Deposit credits an amount of a currency to the SPOT wallet, as if it had been deposited to the deposit address
+// of the currency, & records the deposit.
+func (s *Server) Deposit(currency string, amount decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.account(walletSpot, currency)
+	account.Balance = account.Balance.Add(amount)
+	account.Available = account.Available.Add(amount)
+
+	s.lastID++
+	now := cdctime.Time(s.clock.Now())
+	s.deposits = append(s.deposits, cdcexchange.Deposit{
+		ID:         strconv.FormatInt(s.lastID, 10),
+		Currency:   currency,
+		Amount:     amount,
+		Address:    depositAddress(currency),
+		Status:     cdcexchange.DepositStatusArrived,
+		CreateTime: now,
+		UpdateTime: now,
+	})
+}
+
+func (s *Server) getDepositAddress(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	currency := stringParam(params, "currency")
+	if currency == "" {
+		return nil, codeBadRequest
+	}
+
+	return cdcexchange.GetDepositAddressResult{
+		DepositAddressList: []cdcexchange.DepositAddress{{
+			ID:       "1",
+			Currency: currency,
+			Network:  currency,
+			Address:  depositAddress(currency),
+			Status:   cdcexchange.DepositAddressStatusActive,
+		}},
+	}, 0
+}
+
+// getDepositHistory returns the deposits made with Deposit, most recent first.
+func (s *Server) getDepositHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+	status := cdcexchange.DepositStatus(stringParam(params, "status"))
+
+	deposits := make([]cdcexchange.Deposit, 0)
+	for i := len(s.deposits) - 1; i >= 0; i-- {
+		d := s.deposits[i]
+		if (status == "" || d.Status == status) && filter.matchCurrency(d.Currency, d.CreateTime) {
+			deposits = append(deposits, d)
+		}
+	}
+
+	return cdcexchange.GetDepositHistoryResult{DepositList: page(filter, deposits)}, 0
+}
+
+// createWithdrawal debits a withdrawal from the SPOT wallet, withdrawals complete immediately & are not charged a fee.
+func (s *Server) createWithdrawal(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	var (
+		currency  = stringParam(params, "currency")
+		address   = stringParam(params, "address")
+		clientWID = stringParam(params, "client_wid")
+		networkID = stringParam(params, "network_id")
+	)
+	amount, ok := decimalParam(params, "amount")
+	if !ok || currency == "" || address == "" || amount.Sign() <= 0 {
+		return nil, codeBadRequest
+	}
+
+	if clientWID != "" {
+		for _, w := range s.withdrawals {
+			if w.ClientWID == clientWID {
+				return nil, codeDuplicate
+			}
+		}
+	}
+
+	account := s.account(walletSpot, currency)
+	if amount.GreaterThan(account.Available) {
+		return nil, codeNoBalance
+	}
+	account.Balance = account.Balance.Sub(amount)
+	account.Available = account.Available.Sub(amount)
+
+	if networkID == "" {
+		networkID = currency
+	}
+
+	s.lastID++
+	now := cdctime.Time(s.clock.Now())
+	s.withdrawals = append(s.withdrawals, cdcexchange.Withdrawal{
+		ID:         strconv.FormatInt(s.lastID, 10),
+		ClientWID:  clientWID,
+		NetworkID:  networkID,
+		Currency:   currency,
+		Amount:     amount,
+		Address:    address,
+		Status:     cdcexchange.WithdrawalStatusCompleted,
+		CreateTime: now,
+		UpdateTime: now,
+	})
+
+	return cdcexchange.CreateWithdrawalResult{
+		ID:         s.lastID,
+		Amount:     amount,
+		Symbol:     currency,
+		Address:    address,
+		ClientWID:  clientWID,
+		CreateTime: now,
+	}, 0
+}
+
+// getWithdrawalHistory returns the withdrawals of the account, most recent first.
+func (s *Server) getWithdrawalHistory(_ *http.Request, params map[string]interface{}) (interface{}, int64) {
+	filter, ok := newFilter(params)
+	if !ok {
+		return nil, codeBadRequest
+	}
+	status := cdcexchange.WithdrawalStatus(stringParam(params, "status"))
+
+	withdrawals := make([]cdcexchange.Withdrawal, 0)
+	for i := len(s.withdrawals) - 1; i >= 0; i-- {
+		w := s.withdrawals[i]
+		if (status == "" || w.Status == status) && filter.matchCurrency(w.Currency, w.CreateTime) {
+			withdrawals = append(withdrawals, w)
+		}
+	}
+
+	return cdcexchange.GetWithdrawalHistoryResult{WithdrawalList: page(filter, withdrawals)}, 0
+}
+
+// depositAddress returns the deposit address of a currency.
+func depositAddress(currency string) string {
+	return "cdcexchangetest-" + strings.ToLower(currency)
+}
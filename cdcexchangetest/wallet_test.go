@@ -0,0 +1,68 @@
+package cdcexchangetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+func TestServer_Wallet(t *testing.T) {
+	ctx := context.Background()
+	s, client := newServer(t)
+
+	addresses, err := client.GetDepositAddress(ctx, "BTC")
+	require.NoError(t, err)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, "BTC", addresses[0].Currency)
+	assert.Equal(t, cdcexchange.DepositAddressStatusActive, addresses[0].Status)
+
+	s.Deposit("BTC", d("2"))
+
+	deposits, err := client.GetDepositHistory(ctx, cdcexchange.GetDepositHistoryRequest{Currency: "BTC"})
+	require.NoError(t, err)
+	require.Len(t, deposits.DepositList, 1)
+	assert.Equal(t, d("2"), deposits.DepositList[0].Amount)
+	assert.Equal(t, addresses[0].Address, deposits.DepositList[0].Address)
+	assert.Equal(t, cdcexchange.DepositStatusArrived, deposits.DepositList[0].Status)
+
+	req := cdcexchange.CreateWithdrawalRequest{
+		ClientWID: "some client wid",
+		Currency:  "BTC",
+		Amount:    d("0.5"),
+		Address:   "some address",
+	}
+	withdrawal, err := client.CreateWithdrawal(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, d("0.5"), withdrawal.Amount)
+	assert.Equal(t, "some client wid", withdrawal.ClientWID)
+	assert.Equal(t, d("1.5"), s.Account("BTC").Available)
+
+	// a withdrawal with the same client withdrawal ID is rejected.
+	_, err = client.CreateWithdrawal(ctx, req)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrDuplicateRecord))
+
+	req.ClientWID = ""
+	req.Amount = d("5")
+	_, err = client.CreateWithdrawal(ctx, req)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrNegativeBalance))
+
+	withdrawals, err := client.GetWithdrawalHistory(ctx, cdcexchange.GetWithdrawalHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, withdrawals.WithdrawalList, 1)
+	assert.Equal(t, "some address", withdrawals.WithdrawalList[0].Address)
+	assert.Equal(t, cdcexchange.WithdrawalStatusCompleted, withdrawals.WithdrawalList[0].Status)
+
+	withdrawals, err = client.GetWithdrawalHistory(ctx, cdcexchange.GetWithdrawalHistoryRequest{
+		Status: cdcexchange.WithdrawalStatusPending,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, withdrawals.WithdrawalList)
+}
@@ -2,7 +2,12 @@ package cdcexchange
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 
@@ -10,22 +15,56 @@ import (
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/id"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
-	EnvironmentUATSandbox Environment = "uat_sandbox"
-	EnvironmentProduction Environment = "production"
-
 	uatSandboxBaseURL = "https://uat-api.3ona.co/v2/"
 	productionBaseURL = "https://api.crypto.com/v2/"
+
+	uatSandboxMarketWebsocketURL = "wss://uat-stream.3ona.co/v2/market"
+	productionMarketWebsocketURL = "wss://stream.crypto.com/v2/market"
+
+	uatSandboxUserWebsocketURL = "wss://uat-stream.3ona.co/v2/user"
+	productionUserWebsocketURL = "wss://stream.crypto.com/v2/user"
 )
 
+// EnvironmentUATSandbox returns the UAT sandbox environment.
+func EnvironmentUATSandbox() Environment {
+	return Environment{
+		BaseURL:            uatSandboxBaseURL,
+		MarketWebsocketURL: uatSandboxMarketWebsocketURL,
+		UserWebsocketURL:   uatSandboxUserWebsocketURL,
+	}
+}
+
+// EnvironmentProduction returns the production environment.
+func EnvironmentProduction() Environment {
+	return Environment{
+		BaseURL:            productionBaseURL,
+		MarketWebsocketURL: productionMarketWebsocketURL,
+		UserWebsocketURL:   productionUserWebsocketURL,
+	}
+}
+
 type (
 	// CryptoDotComExchange is a Crypto.com Exchange client for all available APIs.
 	CryptoDotComExchange interface {
 		// UpdateConfig can be used to update the configuration of the client object.
 		// (e.g. change api key, secret key, environment, etc).
 		UpdateConfig(apiKey string, secretKey string, opts ...ClientOption) error
+		// SyncServerTime estimates the offset of the Exchange's clock from the local clock,
+		// so that the nonce of each request is within the window accepted by the Exchange.
+		//
+		// The Exchange's clock is sampled several times, the offset is estimated from the sample with the shortest round trip.
+		// Concurrent syncs are shared, a sync which is already in flight is waited for rather than starting another.
+		// The clock is re-synced automatically if a request is rejected with errors.ErrInvalidNonce.
+		//
+		// Method: public/get-book
+		SyncServerTime(ctx context.Context) error
+		// ServerTimeOffset returns the estimated offset of the Exchange's clock from the local clock.
+		ServerTimeOffset() time.Duration
 		CommonAPI
 		SpotTradingAPI
 		MarginTradingAPI
@@ -40,6 +79,17 @@ type (
 		//
 		// Method: public/get-instruments
 		GetInstruments(ctx context.Context) ([]Instrument, error)
+		// GetInstrument provides information on a supported instrument (e.g. BTC_USDT).
+		//
+		// Instruments are cached, the cache is loaded when first needed and reloaded once the refresh interval has passed
+		// or an unknown instrument is requested.
+		//
+		// Method: public/get-instruments
+		GetInstrument(ctx context.Context, instrumentName string) (*Instrument, error)
+		// RefreshInstruments reloads the cache of instruments used by GetInstrument.
+		//
+		// Method: public/get-instruments
+		RefreshInstruments(ctx context.Context) error
 		// GetBook fetches the public order book for a particular instrument and depth.
 		//
 		// Method: public/get-book
@@ -50,6 +100,49 @@ type (
 		//
 		// Method: public/get-ticker
 		GetTickers(ctx context.Context, instrument string) ([]Ticker, error)
+		// GetCandlesticks fetches the public candlesticks (k-line data history) for a particular instrument and interval.
+		//
+		// Method: public/get-candlestick
+		GetCandlesticks(ctx context.Context, instrument string, interval Interval) ([]Candlestick, error)
+		// GetPublicTrades fetches the public trades executed on the Exchange for a particular instrument (e.g. BTC_USDT).
+		//
+		// Unlike GetTrades, this returns trades executed by any user and does not require authentication.
+		//
+		// instrument can be left blank to retrieve trades for ALL instruments.
+		//
+		// Method: public/get-trades
+		GetPublicTrades(ctx context.Context, instrument string) ([]MarketTrade, error)
+		// CreateWithdrawal creates a withdrawal request.
+		//
+		// Withdrawal setting must be enabled for your API Key, and the withdrawal address must be whitelisted.
+		//
+		// req.ClientWID is optional, but recommended so that a failed request can be retried without
+		// risking a duplicate withdrawal.
+		//
+		// Method: private/create-withdrawal
+		CreateWithdrawal(ctx context.Context, req CreateWithdrawalRequest) (*CreateWithdrawalResult, error)
+		// GetWithdrawalHistory gets the withdrawal history of the account.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
+		//
+		// Method: private/get-withdrawal-history
+		GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHistoryRequest) (*GetWithdrawalHistoryResult, error)
+		// GetDepositHistory gets the deposit history of the account.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
+		//
+		// Method: private/get-deposit-history
+		GetDepositHistory(ctx context.Context, req GetDepositHistoryRequest) (*GetDepositHistoryResult, error)
+		// GetDepositAddress fetches the deposit addresses of a particular currency.
+		//
+		// A currency may have a deposit address on each network it supports.
+		//
+		// Method: private/get-deposit-address
+		GetDepositAddress(ctx context.Context, currency string) ([]DepositAddress, error)
 	}
 
 	// SpotTradingAPI is a Crypto.com Exchange client for Spot Trading API.
@@ -87,15 +180,17 @@ type (
 		// GetOrderHistory gets the order history for a particular instrument.
 		//
 		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-		// If paging is used, enumerate each page (starting with 0) until an empty order_list array appears in the response.
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
 		//
 		// req.InstrumentName can be left blank to get open orders for all instruments.
 		//
 		// Method: private/get-order-history
-		GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest) ([]Order, error)
+		GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest) (*GetOrderHistoryResult, error)
 		// GetOpenOrders gets all open orders for a particular instrument.
 		//
 		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
 		//
 		// req.InstrumentName can be left blank to get open orders for all instruments.
 		//
@@ -108,44 +203,368 @@ type (
 		// GetTrades gets all executed trades for a particular instrument.
 		//
 		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-		// If paging is used, enumerate each page (starting with 0) until an empty trade_list array appears in the response.
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
 		//
 		// req.InstrumentName can be left blank to get executed trades for all instruments.
 		//
 		// Method: private/get-trades
-		GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade, error)
+		GetTrades(ctx context.Context, req GetTradesRequest) (*GetTradesResult, error)
+		// IterateOrderHistory iterates over the order history for a particular instrument, starting from req.Page.
+		//
+		// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+		// req.End defaults to now if only req.Start is set.
+		//
+		// Method: private/get-order-history
+		IterateOrderHistory(ctx context.Context, req GetOrderHistoryRequest) *OrderIterator
+		// IterateOpenOrders iterates over all open orders for a particular instrument, starting from req.Page.
+		//
+		// Method: private/get-open-orders
+		IterateOpenOrders(ctx context.Context, req GetOpenOrdersRequest) *OrderIterator
+		// IterateTrades iterates over all executed trades for a particular instrument, starting from req.Page.
+		//
+		// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+		// req.End defaults to now if only req.Start is set.
+		//
+		// Method: private/get-trades
+		IterateTrades(ctx context.Context, req GetTradesRequest) *TradeIterator
 	}
 
 	// MarginTradingAPI is a Crypto.com Exchange client for Margin Trading API.
 	MarginTradingAPI interface {
+		// GetMarginTransferCurrencies fetches the currencies which can be transferred to the margin wallet.
+		//
+		// Method: public/margin/get-transfer-currencies
+		GetMarginTransferCurrencies(ctx context.Context) ([]string, error)
+		// GetMarginLoanCurrencies fetches the currencies which can be borrowed on margin.
+		//
+		// Method: public/margin/get-loan-currencies
+		GetMarginLoanCurrencies(ctx context.Context) ([]string, error)
+		// GetMarginUserConfig fetches the margin trading configuration of the user.
+		//
+		// Method: private/margin/get-user-config
+		GetMarginUserConfig(ctx context.Context) (*MarginUserConfig, error)
+		// GetMarginAccountSummary returns the margin account balance of a user for a particular token.
+		//
+		// currency can be left blank to retrieve balances for ALL tokens.
+		//
+		// Method: private/margin/get-account-summary
+		GetMarginAccountSummary(ctx context.Context, currency string) (*MarginAccountSummary, error)
+		// MarginTransfer transfers funds between the spot and margin wallets.
+		//
+		// Method: private/margin/transfer
+		MarginTransfer(ctx context.Context, req MarginTransferRequest) error
+		// MarginBorrow borrows an amount of a particular currency into the margin wallet.
+		//
+		// Method: private/margin/borrow
+		MarginBorrow(ctx context.Context, currency string, amount decimal.Decimal) error
+		// MarginRepay repays an amount of a particular borrowed currency from the margin wallet.
+		//
+		// Accrued interest is repaid before the borrowed amount.
+		//
+		// Method: private/margin/repay
+		MarginRepay(ctx context.Context, currency string, amount decimal.Decimal) error
+		// GetMarginTransferHistory gets the history of transfers between the spot and margin wallets.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-transfer-history
+		GetMarginTransferHistory(ctx context.Context, req GetMarginTransferHistoryRequest) (*GetMarginTransferHistoryResult, error)
+		// GetMarginBorrowHistory gets the history of amounts borrowed on margin.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-borrow-history
+		GetMarginBorrowHistory(ctx context.Context, req GetMarginBorrowHistoryRequest) (*GetMarginBorrowHistoryResult, error)
+		// GetMarginInterestHistory gets the history of interest charged on borrowed amounts.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-interest-history
+		GetMarginInterestHistory(ctx context.Context, req GetMarginInterestHistoryRequest) (*GetMarginInterestHistoryResult, error)
+		// GetMarginRepayHistory gets the history of repayments of borrowed amounts.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-repay-history
+		GetMarginRepayHistory(ctx context.Context, req GetMarginRepayHistoryRequest) (*GetMarginRepayHistoryResult, error)
+		// GetMarginLiquidationHistory gets the history of liquidations of the margin account.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-liquidation-history
+		GetMarginLiquidationHistory(ctx context.Context, req GetMarginLiquidationHistoryRequest) (*GetMarginLiquidationHistoryResult, error)
+		// GetMarginLiquidationOrders gets the orders placed to liquidate the margin account.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-liquidation-orders
+		GetMarginLiquidationOrders(ctx context.Context, req GetMarginLiquidationOrdersRequest) (*GetMarginLiquidationOrdersResult, error)
+		// CreateMarginOrder creates a new BUY or SELL margin order on the Exchange.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// The user.margin.order subscription can be used to check when the order is successfully created.
+		//
+		// Method: private/margin/create-order
+		CreateMarginOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error)
+		// CancelMarginOrder cancels an existing margin order on the Exchange.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// The user.margin.order subscription can be used to check when the order is successfully cancelled.
+		//
+		// Method: private/margin/cancel-order
+		CancelMarginOrder(ctx context.Context, instrumentName string, orderID string) error
+		// CancelAllMarginOrders cancels all margin orders for a particular instrument/pair.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// The user.margin.order subscription can be used to check when the orders are successfully cancelled.
+		//
+		// Method: private/margin/cancel-all-orders
+		CancelAllMarginOrders(ctx context.Context, instrumentName string) error
+		// GetMarginOrderHistory gets the margin order history for a particular instrument.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
+		//
+		// req.InstrumentName can be left blank to get orders for all instruments.
+		//
+		// Method: private/margin/get-order-history
+		GetMarginOrderHistory(ctx context.Context, req GetOrderHistoryRequest) (*GetOrderHistoryResult, error)
+		// GetMarginOpenOrders gets all open margin orders for a particular instrument.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		//
+		// req.InstrumentName can be left blank to get open orders for all instruments.
+		//
+		// Method: private/margin/get-open-orders
+		GetMarginOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*GetOpenOrdersResult, error)
+		// GetMarginOrderDetail gets details of a margin order for a particular order ID.
+		//
+		// Method: private/margin/get-order-detail
+		GetMarginOrderDetail(ctx context.Context, orderID string) (*GetOrderDetailResult, error)
+		// GetMarginTrades gets all executed margin trades for a particular instrument.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+		// HasMore may be true when no further results exist, in which case the next page is empty.
+		//
+		// req.InstrumentName can be left blank to get executed trades for all instruments.
+		//
+		// Method: private/margin/get-trades
+		GetMarginTrades(ctx context.Context, req GetTradesRequest) (*GetTradesResult, error)
+		// IterateMarginOrderHistory iterates over the margin order history for a particular instrument, starting from req.Page.
+		//
+		// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+		// req.End defaults to now if only req.Start is set.
+		//
+		// Method: private/margin/get-order-history
+		IterateMarginOrderHistory(ctx context.Context, req GetOrderHistoryRequest) *OrderIterator
+		// IterateMarginOpenOrders iterates over all open margin orders for a particular instrument, starting from req.Page.
+		//
+		// Method: private/margin/get-open-orders
+		IterateMarginOpenOrders(ctx context.Context, req GetOpenOrdersRequest) *OrderIterator
+		// IterateMarginTrades iterates over all executed margin trades for a particular instrument, starting from req.Page.
+		//
+		// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+		// req.End defaults to now if only req.Start is set.
+		//
+		// Method: private/margin/get-trades
+		IterateMarginTrades(ctx context.Context, req GetTradesRequest) *TradeIterator
 	}
 
 	// DerivativesTransferAPI is a Crypto.com Exchange client for Derivatives Transfer API.
 	DerivativesTransferAPI interface {
+		// DerivTransfer transfers funds between the spot and derivatives wallets.
+		//
+		// Method: private/deriv/transfer
+		DerivTransfer(ctx context.Context, req DerivTransferRequest) error
+		// GetDerivTransferHistory gets the history of transfers between the spot and derivatives wallets.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/deriv/get-transfer-history
+		GetDerivTransferHistory(ctx context.Context, req GetDerivTransferHistoryRequest) (*GetDerivTransferHistoryResult, error)
 	}
 
 	// SubAccountAPI is a Crypto.com Exchange client for Sub-account API.
 	SubAccountAPI interface {
+		// GetSubAccounts gets all sub-accounts of the master account.
+		//
+		// Method: private/subaccount/get-sub-accounts
+		GetSubAccounts(ctx context.Context) ([]SubAccount, error)
+		// GetSubAccountTransferHistory gets the history of transfers between the master account and sub-accounts.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/subaccount/get-transfer-history
+		GetSubAccountTransferHistory(ctx context.Context, req GetSubAccountTransferHistoryRequest) (*GetSubAccountTransferHistoryResult, error)
+		// SubAccountTransfer transfers funds between the master account and a sub-account, or between sub-accounts.
+		//
+		// Method: private/subaccount/transfer
+		SubAccountTransfer(ctx context.Context, req SubAccountTransferRequest) error
 	}
 
 	// Websocket is a Crypto.com Exchange client websocket methods & channels.
+	//
+	// Heartbeats sent by the Exchange are responded to automatically (public/respond-heartbeat),
+	// a WebsocketEventHeartbeatMissed event is emitted if a heartbeat is not received in time.
+	//
+	// If a connection is lost it is re-established with a jittered backoff, user connections are re-authenticated
+	// and all active subscriptions are replayed. Subscription channels remain open while the connection is re-established,
+	// the state of the connection is reported by WebsocketEventReconnecting, WebsocketEventConnected
+	// and WebsocketEventResubscribed events.
 	Websocket interface {
+		// NewMarketWebsocket opens a new connection to the market data websocket.
+		//
+		// The connection should be closed once it is no longer needed.
+		NewMarketWebsocket(ctx context.Context) (MarketWebsocket, error)
+		// NewUserWebsocket opens a new connection to the user websocket, authenticating with the client's API key.
+		//
+		// The connection should be closed once it is no longer needed.
+		//
+		// Method: public/auth
+		NewUserWebsocket(ctx context.Context) (UserWebsocket, error)
+	}
+
+	// MarketWebsocket is a connection to the Crypto.com Exchange market data websocket.
+	MarketWebsocket interface {
+		// SubscribeBook subscribes to order book updates for a particular instrument and depth.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: book.{instrument_name}.{depth}
+		SubscribeBook(ctx context.Context, instrument string, depth int) (<-chan BookResult, error)
+		// UnsubscribeBook cancels a subscription to order book updates.
+		//
+		// Channel: book.{instrument_name}.{depth}
+		UnsubscribeBook(ctx context.Context, instrument string, depth int) error
+		// SubscribeTicker subscribes to ticker updates for a particular instrument.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: ticker.{instrument_name}
+		SubscribeTicker(ctx context.Context, instrument string) (<-chan Ticker, error)
+		// UnsubscribeTicker cancels a subscription to ticker updates.
+		//
+		// Channel: ticker.{instrument_name}
+		UnsubscribeTicker(ctx context.Context, instrument string) error
+		// SubscribeTrades subscribes to trades executed for a particular instrument.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: trade.{instrument_name}
+		SubscribeTrades(ctx context.Context, instrument string) (<-chan MarketTrade, error)
+		// UnsubscribeTrades cancels a subscription to executed trades.
+		//
+		// Channel: trade.{instrument_name}
+		UnsubscribeTrades(ctx context.Context, instrument string) error
+		// Events returns a channel on which connection events are delivered (e.g. reconnecting, a missed heartbeat).
+		//
+		// The channel is closed once the connection is closed.
+		Events() <-chan WebsocketEvent
+		// Close closes the connection, along with all of its subscriptions.
+		Close() error
+	}
+
+	// UserWebsocket is an authenticated connection to the Crypto.com Exchange user websocket.
+	UserWebsocket interface {
+		// SubscribeOrders subscribes to updates of the user's orders for a particular instrument.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: user.order.{instrument_name}
+		SubscribeOrders(ctx context.Context, instrument string) (<-chan Order, error)
+		// UnsubscribeOrders cancels a subscription to order updates.
+		//
+		// Channel: user.order.{instrument_name}
+		UnsubscribeOrders(ctx context.Context, instrument string) error
+		// SubscribeTrades subscribes to trades executed by the user for a particular instrument.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: user.trade.{instrument_name}
+		SubscribeTrades(ctx context.Context, instrument string) (<-chan Trade, error)
+		// UnsubscribeTrades cancels a subscription to the user's executed trades.
+		//
+		// Channel: user.trade.{instrument_name}
+		UnsubscribeTrades(ctx context.Context, instrument string) error
+		// SubscribeBalance subscribes to updates of the user's balances.
+		//
+		// The returned channel is closed once the subscription is cancelled or the connection is closed.
+		//
+		// Channel: user.balance
+		SubscribeBalance(ctx context.Context) (<-chan Account, error)
+		// UnsubscribeBalance cancels a subscription to balance updates.
+		//
+		// Channel: user.balance
+		UnsubscribeBalance(ctx context.Context) error
+		// EnableCancelOnDisconnect enables cancel-on-disconnect for the session, so that open orders are cancelled
+		// by the Exchange if the connection is lost.
+		//
+		// The scope is re-applied each time the connection is re-established.
+		//
+		// Method: private/set-cancel-on-disconnect
+		EnableCancelOnDisconnect(ctx context.Context, scope CancelOnDisconnectScope) error
+		// DisableCancelOnDisconnect disables cancel-on-disconnect for the session.
+		//
+		// The Exchange binds the scope to the session and does not allow it to be removed, so the session is replaced
+		// by a new connection without the scope. As the previous session is disconnected, the Exchange cancels
+		// the orders within its scope one last time.
+		DisableCancelOnDisconnect(ctx context.Context) error
+		// GetCancelOnDisconnect fetches the cancel-on-disconnect scope applied to the session.
+		//
+		// Method: private/get-cancel-on-disconnect
+		GetCancelOnDisconnect(ctx context.Context) (CancelOnDisconnectScope, error)
+		// Events returns a channel on which connection events are delivered (e.g. reconnecting, a missed heartbeat).
+		//
+		// The channel is closed once the connection is closed.
+		Events() <-chan WebsocketEvent
+		// Close closes the connection, along with all of its subscriptions.
+		Close() error
 	}
 
-	// Environment represents the environment against which calls are made.
-	Environment string
+	// Environment represents the environment against which calls are made,
+	// any environment can be used by specifying its URLs (e.g. a proxy, a regional endpoint or a local fake).
+	Environment struct {
+		// BaseURL is the base URL of the REST API, which each method is appended to (e.g. https://api.crypto.com/v2/).
+		BaseURL string
+		// MarketWebsocketURL is the URL of the market data websocket (e.g. wss://stream.crypto.com/v2/market).
+		MarketWebsocketURL string
+		// UserWebsocketURL is the URL of the user websocket (e.g. wss://stream.crypto.com/v2/user).
+		UserWebsocketURL string
+	}
 
 	// ClientOption represents optional configurations for the client.
 	ClientOption func(*client) error
 
 	// client is a concrete implementation of CryptoDotComExchange.
 	client struct {
-		apiKey             string
-		secretKey          string
-		clock              clockwork.Clock
-		idGenerator        id.IDGenerator
-		signatureGenerator auth.SignatureGenerator
-		requester          api.Requester
+		apiKey                    string
+		secretKey                 string
+		clock                     clockwork.Clock
+		idGenerator               id.IDGenerator
+		signatureGenerator        auth.SignatureGenerator
+		requester                 api.Requester
+		marketWebsocketURL        string
+		userWebsocketURL          string
+		websocketHeartbeatTimeout time.Duration
+		websocketReconnectBackoff websocketReconnectBackoff
+		rateLimits                map[string]RateLimit
+		rateLimitDisabled         bool
+		// rateLimitsUpdated is set by the rate limit options, so that the limiter is only rebuilt if they are applied.
+		rateLimitsUpdated         bool
+		retryPolicy               RetryPolicy
+		serverTimeOffset          *serverTimeOffset
+		precisionMode             PrecisionMode
+		instrumentRefreshInterval time.Duration
+		instruments               *instrumentRegistry
+		interceptors              []Interceptor
+		logger                    *slog.Logger
 	}
 )
 
@@ -159,6 +578,24 @@ func New(apiKey string, secretKey string, opts ...ClientOption) (*client, error)
 			Client:  http.DefaultClient,
 			BaseURL: productionBaseURL,
 		},
+		marketWebsocketURL:        productionMarketWebsocketURL,
+		userWebsocketURL:          productionUserWebsocketURL,
+		websocketHeartbeatTimeout: defaultWebsocketHeartbeatTimeout,
+		websocketReconnectBackoff: websocketReconnectBackoff{
+			min: defaultWebsocketReconnectMinBackoff,
+			max: defaultWebsocketReconnectMaxBackoff,
+		},
+		rateLimits:                make(map[string]RateLimit, len(defaultRateLimits)),
+		rateLimitsUpdated:         true,
+		serverTimeOffset:          &serverTimeOffset{},
+		precisionMode:             PrecisionModeNone,
+		instrumentRefreshInterval: defaultInstrumentRefreshInterval,
+		instruments:               &instrumentRegistry{},
+	}
+	c.requester.ResyncNonce = c.resyncNonce
+
+	for method, limit := range defaultRateLimits {
+		c.rateLimits[method] = limit
 	}
 
 	if err := c.UpdateConfig(apiKey, secretKey, opts...); err != nil {
@@ -181,32 +618,129 @@ func (c *client) UpdateConfig(apiKey string, secretKey string, opts ...ClientOpt
 	c.apiKey = apiKey
 	c.secretKey = secretKey
 
+	clock := c.clock
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
 			return err
 		}
 	}
 
+	// the limiter & retry policy are built once all options are applied, as they depend on the clock.
+	// The limiter is kept unless the rate limits or clock are changed, so that the requests already made still count.
+	if c.rateLimitsUpdated || c.clock != clock {
+		c.requester.Limiter = nil
+		if limiter := c.newRateLimiter(); limiter != nil {
+			c.requester.Limiter = limiter
+		}
+		c.rateLimitsUpdated = false
+	}
+	c.requester.Retry = c.newRetryPolicy()
+	c.requester.Clock = c.clock
+	c.requester.Interceptors = c.newInterceptors()
+
 	return nil
 }
 
 // WithProductionEnvironment will initialise the client to make requests against the production environment.
 // This is the default setting.
 func WithProductionEnvironment() ClientOption {
+	return WithEnvironment(EnvironmentProduction())
+}
+
+// WithUATEnvironment will initialise the client to make requests against the UAT sandbox environment.
+func WithUATEnvironment() ClientOption {
+	return WithEnvironment(EnvironmentUATSandbox())
+}
+
+// WithEnvironment will initialise the client to make requests against the URLs of the specified environment.
+func WithEnvironment(env Environment) ClientOption {
 	return func(c *client) error {
-		c.requester.BaseURL = productionBaseURL
+		// all of the URLs are validated before any are set, so that the client is not left with a mix of environments.
+		if err := validateURL("env.BaseURL", env.BaseURL, "http", "https"); err != nil {
+			return err
+		}
+		if err := validateURL("env.MarketWebsocketURL", env.MarketWebsocketURL, "ws", "wss"); err != nil {
+			return err
+		}
+		if err := validateURL("env.UserWebsocketURL", env.UserWebsocketURL, "ws", "wss"); err != nil {
+			return err
+		}
+
+		c.requester.BaseURL = withTrailingSlash(env.BaseURL)
+		c.marketWebsocketURL = env.MarketWebsocketURL
+		c.userWebsocketURL = env.UserWebsocketURL
 		return nil
 	}
 }
 
-// WithUATEnvironment will initialise the client to make requests against the UAT sandbox environment.
-func WithUATEnvironment() ClientOption {
+// WithBaseURL sets the base URL of the REST API (Default: https://api.crypto.com/v2/),
+// which must be an absolute http or https URL. A trailing slash is added if it is missing.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *client) error {
+		if err := validateURL("baseURL", baseURL, "http", "https"); err != nil {
+			return err
+		}
+
+		c.requester.BaseURL = withTrailingSlash(baseURL)
+		return nil
+	}
+}
+
+// WithMarketWebsocketURL sets the URL of the market data websocket (Default: wss://stream.crypto.com/v2/market),
+// which must be an absolute ws or wss URL.
+func WithMarketWebsocketURL(marketWebsocketURL string) ClientOption {
 	return func(c *client) error {
-		c.requester.BaseURL = uatSandboxBaseURL
+		if err := validateURL("marketWebsocketURL", marketWebsocketURL, "ws", "wss"); err != nil {
+			return err
+		}
+
+		c.marketWebsocketURL = marketWebsocketURL
 		return nil
 	}
 }
 
+// WithUserWebsocketURL sets the URL of the user websocket (Default: wss://stream.crypto.com/v2/user),
+// which must be an absolute ws or wss URL.
+func WithUserWebsocketURL(userWebsocketURL string) ClientOption {
+	return func(c *client) error {
+		if err := validateURL("userWebsocketURL", userWebsocketURL, "ws", "wss"); err != nil {
+			return err
+		}
+
+		c.userWebsocketURL = userWebsocketURL
+		return nil
+	}
+}
+
+// withTrailingSlash returns baseURL ending with a slash, so that methods can be appended to it.
+func withTrailingSlash(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/") {
+		return baseURL
+	}
+
+	return baseURL + "/"
+}
+
+// validateURL checks that rawURL is an absolute URL with one of the specified schemes.
+func validateURL(parameter, rawURL string, schemes ...string) error {
+	if rawURL == "" {
+		return errors.InvalidParameterError{Parameter: parameter, Reason: "cannot be empty"}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return errors.InvalidParameterError{Parameter: parameter, Reason: "must be an absolute URL"}
+	}
+
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+
+	return errors.InvalidParameterError{Parameter: parameter, Reason: fmt.Sprintf("must use the %s scheme", strings.Join(schemes, " or "))}
+}
+
 // WithHTTPClient will allow the client to be initialised with a custom http client.
 // Can be used to create custom timeouts, enable tracing, etc.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
@@ -219,3 +753,42 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 		return nil
 	}
 }
+
+// WithWebsocketHeartbeatTimeout sets the maximum time allowed between heartbeats from the Exchange
+// before a websocket connection is considered stale (Default: 1 minute).
+// Heartbeats are not monitored if timeout is 0.
+func WithWebsocketHeartbeatTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) error {
+		if timeout < 0 {
+			return errors.InvalidParameterError{Parameter: "timeout", Reason: "cannot be less than 0"}
+		}
+
+		c.websocketHeartbeatTimeout = timeout
+		return nil
+	}
+}
+
+// WithWebsocketReconnectBackoff sets the bounds of the delay between attempts to re-establish a lost websocket connection
+// (Default: 500 milliseconds - 30 seconds). The delay doubles with each failed attempt up to maxBackoff.
+func WithWebsocketReconnectBackoff(minBackoff, maxBackoff time.Duration) ClientOption {
+	return func(c *client) error {
+		switch {
+		case minBackoff <= 0:
+			return errors.InvalidParameterError{Parameter: "minBackoff", Reason: "must be greater than 0"}
+		case maxBackoff < minBackoff:
+			return errors.InvalidParameterError{Parameter: "maxBackoff", Reason: "cannot be less than minBackoff"}
+		}
+
+		c.websocketReconnectBackoff = websocketReconnectBackoff{min: minBackoff, max: maxBackoff}
+		return nil
+	}
+}
+
+// WithWebsocketReconnectDisabled stops lost websocket connections from being re-established,
+// the connection is closed along with all of its subscriptions instead.
+func WithWebsocketReconnectDisabled() ClientOption {
+	return func(c *client) error {
+		c.websocketReconnectBackoff = websocketReconnectBackoff{}
+		return nil
+	}
+}
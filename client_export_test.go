@@ -14,10 +14,27 @@ const (
 	UATSandboxBaseURL = uatSandboxBaseURL
 	ProductionBaseURL = productionBaseURL
 
+	UATSandboxMarketWebsocketURL = uatSandboxMarketWebsocketURL
+	ProductionMarketWebsocketURL = productionMarketWebsocketURL
+
+	UATSandboxUserWebsocketURL = uatSandboxUserWebsocketURL
+	ProductionUserWebsocketURL = productionUserWebsocketURL
+
+	// Websocket
+	MethodAuth                  = methodAuth
+	MethodSetCancelOnDisconnect = methodSetCancelOnDisconnect
+	MethodGetCancelOnDisconnect = methodGetCancelOnDisconnect
+
 	// Common API
-	MethodGetInstruments = methodGetInstruments
-	MethodGetBook        = methodGetBook
-	MethodGetTicker      = methodGetTicker
+	MethodGetInstruments       = methodGetInstruments
+	MethodGetBook              = methodGetBook
+	MethodGetTicker            = methodGetTicker
+	MethodGetCandlestick       = methodGetCandlestick
+	MethodGetPublicTrades      = methodGetPublicTrades
+	MethodCreateWithdrawal     = methodCreateWithdrawal
+	MethodGetWithdrawalHistory = methodGetWithdrawalHistory
+	MethodGetDepositHistory    = methodGetDepositHistory
+	MethodGetDepositAddress    = methodGetDepositAddress
 
 	// Spot Trading API
 	MethodGetAccountSummary = methodGetAccountSummary
@@ -28,12 +45,51 @@ const (
 	MethodGetOpenOrders     = methodGetOpenOrders
 	MethodGetOrderDetail    = methodGetOrderDetail
 	MethodGetTrades         = methodGetTrades
+
+	// Margin Trading API
+	MethodGetMarginTransferCurrencies = methodGetMarginTransferCurrencies
+	MethodGetMarginLoanCurrencies     = methodGetMarginLoanCurrencies
+	MethodGetMarginUserConfig         = methodGetMarginUserConfig
+	MethodGetMarginAccountSummary     = methodGetMarginAccountSummary
+	MethodMarginTransfer              = methodMarginTransfer
+	MethodMarginBorrow                = methodMarginBorrow
+	MethodMarginRepay                 = methodMarginRepay
+	MethodGetMarginTransferHistory    = methodGetMarginTransferHistory
+	MethodGetMarginBorrowHistory      = methodGetMarginBorrowHistory
+	MethodGetMarginInterestHistory    = methodGetMarginInterestHistory
+	MethodGetMarginRepayHistory       = methodGetMarginRepayHistory
+	MethodGetMarginLiquidationHistory = methodGetMarginLiquidationHistory
+	MethodGetMarginLiquidationOrders  = methodGetMarginLiquidationOrders
+	MethodCreateMarginOrder           = methodCreateMarginOrder
+	MethodCancelMarginOrder           = methodCancelMarginOrder
+	MethodCancelAllMarginOrders       = methodCancelAllMarginOrders
+	MethodGetMarginOrderHistory       = methodGetMarginOrderHistory
+	MethodGetMarginOpenOrders         = methodGetMarginOpenOrders
+	MethodGetMarginOrderDetail        = methodGetMarginOrderDetail
+	MethodGetMarginTrades             = methodGetMarginTrades
+
+	// Derivatives Transfer API
+	MethodDerivTransfer           = methodDerivTransfer
+	MethodGetDerivTransferHistory = methodGetDerivTransferHistory
+
+	// Sub-account API
+	MethodGetSubAccounts               = methodGetSubAccounts
+	MethodGetSubAccountTransferHistory = methodGetSubAccountTransferHistory
+	MethodSubAccountTransfer           = methodSubAccountTransfer
 )
 
 func (c client) BaseURL() string {
 	return c.requester.BaseURL
 }
 
+func (c client) MarketWebsocketURL() string {
+	return c.marketWebsocketURL
+}
+
+func (c client) UserWebsocketURL() string {
+	return c.userWebsocketURL
+}
+
 func (c client) APIKey() string {
 	return c.apiKey
 }
@@ -78,14 +134,3 @@ func WithClock(clock clockwork.Clock) ClientOption {
 		return nil
 	}
 }
-
-func WithBaseURL(url string) ClientOption {
-	return func(c *client) error {
-		if url == "" {
-			return errors.InvalidParameterError{Parameter: "url", Reason: "cannot be empty"}
-		}
-
-		c.requester.BaseURL = url
-		return nil
-	}
-}
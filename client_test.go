@@ -2,6 +2,7 @@ package cdcexchange_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -48,6 +49,7 @@ func TestNew_Error(t *testing.T) {
 	type args struct {
 		apiKey    string
 		secretKey string
+		opts      []cdcexchange.ClientOption
 	}
 	tests := []struct {
 		name string
@@ -69,10 +71,199 @@ func TestNew_Error(t *testing.T) {
 			},
 			expectedErr: errors.InvalidParameterError{Parameter: "secretKey", Reason: "cannot be empty"},
 		},
+		{
+			name: "error when websocket heartbeat timeout is negative",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithWebsocketHeartbeatTimeout(-time.Second)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "timeout", Reason: "cannot be less than 0"},
+		},
+		{
+			name: "error when websocket reconnect min backoff is not positive",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithWebsocketReconnectBackoff(0, time.Second)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "minBackoff", Reason: "must be greater than 0"},
+		},
+		{
+			name: "error when websocket reconnect max backoff is less than min backoff",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithWebsocketReconnectBackoff(time.Second, time.Millisecond)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "maxBackoff", Reason: "cannot be less than minBackoff"},
+		},
+		{
+			name: "error when rate limit method is empty",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithRateLimits(map[string]cdcexchange.RateLimit{
+					"": {Requests: 1, Interval: time.Second},
+				})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "limits", Reason: "method cannot be empty"},
+		},
+		{
+			name: "error when rate limit requests is not positive",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithRateLimits(map[string]cdcexchange.RateLimit{
+					cdcexchange.MethodCreateOrder: {Requests: 0, Interval: time.Second},
+				})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "limits[private/create-order].Requests", Reason: "must be greater than 0"},
+		},
+		{
+			name: "error when rate limit interval is not positive",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithRateLimits(map[string]cdcexchange.RateLimit{
+					cdcexchange.MethodCreateOrder: {Requests: 1, Interval: 0},
+				})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "limits[private/create-order].Interval", Reason: "must be greater than 0"},
+		},
+		{
+			name: "error when max retries is negative",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{MaxRetries: -1})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "policy.MaxRetries", Reason: "cannot be less than 0"},
+		},
+		{
+			name: "error when retry min backoff is not positive",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{MaxRetries: 1, MaxBackoff: time.Second})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "must be greater than 0"},
+		},
+		{
+			name: "error when retry max backoff is less than min backoff",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{
+					MaxRetries: 1,
+					MinBackoff: time.Second,
+					MaxBackoff: time.Millisecond,
+				})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"},
+		},
+		{
+			name: "error when interceptor is nil",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithInterceptors(
+					func(ctx context.Context, call cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+						return invoke(ctx, call)
+					},
+					nil,
+				)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "interceptors[1]", Reason: "cannot be empty"},
+		},
+		{
+			name: "error when logger is nil",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithLogger(nil)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "logger", Reason: "cannot be empty"},
+		},
+		{
+			name: "error when precision mode is invalid",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithPrecisionMode("some mode")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "mode", Reason: "is not a valid precision mode"},
+		},
+		{
+			name: "error when instrument refresh interval is 0",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithInstrumentRefreshInterval(0)},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "interval", Reason: "must be greater than 0"},
+		},
+		{
+			name: "error when base url is empty",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithBaseURL("")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "baseURL", Reason: "cannot be empty"},
+		},
+		{
+			name: "error when base url is relative",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithBaseURL("/v2/")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "baseURL", Reason: "must be an absolute URL"},
+		},
+		{
+			name: "error when base url is not http",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithBaseURL("wss://stream.crypto.com/v2/")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "baseURL", Reason: "must use the http or https scheme"},
+		},
+		{
+			name: "error when market websocket url is not ws",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithMarketWebsocketURL("https://stream.crypto.com/v2/market")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "marketWebsocketURL", Reason: "must use the ws or wss scheme"},
+		},
+		{
+			name: "error when user websocket url is empty",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts:      []cdcexchange.ClientOption{cdcexchange.WithUserWebsocketURL("")},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "userWebsocketURL", Reason: "cannot be empty"},
+		},
+		{
+			name: "error when environment url is invalid",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithEnvironment(cdcexchange.Environment{
+					BaseURL:            cdcexchange.ProductionBaseURL,
+					MarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+				})},
+			},
+			expectedErr: errors.InvalidParameterError{Parameter: "env.UserWebsocketURL", Reason: "cannot be empty"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := cdcexchange.New(tt.apiKey, tt.secretKey)
+			client, err := cdcexchange.New(tt.apiKey, tt.secretKey, tt.opts...)
 			require.Error(t, err)
 
 			assert.Empty(t, client)
@@ -91,7 +282,9 @@ func TestNew_Success(t *testing.T) {
 	tests := []struct {
 		name string
 		args
-		expectedBaseURL string
+		expectedBaseURL            string
+		expectedMarketWebsocketURL string
+		expectedUserWebsocketURL   string
 	}{
 		{
 			name: "successfully creates UAT client",
@@ -100,7 +293,9 @@ func TestNew_Success(t *testing.T) {
 				secretKey: "secret key",
 				opts:      []cdcexchange.ClientOption{cdcexchange.WithUATEnvironment()},
 			},
-			expectedBaseURL: cdcexchange.UATSandboxBaseURL,
+			expectedBaseURL:            cdcexchange.UATSandboxBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.UATSandboxMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.UATSandboxUserWebsocketURL,
 		},
 		{
 			name: "successfully creates production client",
@@ -108,7 +303,54 @@ func TestNew_Success(t *testing.T) {
 				apiKey:    "api key",
 				secretKey: "secret key",
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
+		},
+		{
+			name: "successfully creates client with custom environment",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithEnvironment(cdcexchange.Environment{
+					BaseURL:            "http://localhost:8080/v2",
+					MarketWebsocketURL: "ws://localhost:8080/v2/market",
+					UserWebsocketURL:   "ws://localhost:8080/v2/user",
+				})},
+			},
+			expectedBaseURL:            "http://localhost:8080/v2/",
+			expectedMarketWebsocketURL: "ws://localhost:8080/v2/market",
+			expectedUserWebsocketURL:   "ws://localhost:8080/v2/user",
+		},
+		{
+			name: "successfully creates client with modified production environment",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{cdcexchange.WithEnvironment(func() cdcexchange.Environment {
+					env := cdcexchange.EnvironmentProduction()
+					env.BaseURL = "http://localhost:8080/v2/"
+					return env
+				}())},
+			},
+			expectedBaseURL:            "http://localhost:8080/v2/",
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
+		},
+		{
+			name: "successfully creates client with custom urls",
+			args: args{
+				apiKey:    "api key",
+				secretKey: "secret key",
+				opts: []cdcexchange.ClientOption{
+					cdcexchange.WithUATEnvironment(),
+					cdcexchange.WithBaseURL("https://proxy.example.com/crypto/v2/"),
+					cdcexchange.WithMarketWebsocketURL("wss://proxy.example.com/crypto/v2/market"),
+				},
+			},
+			expectedBaseURL:            "https://proxy.example.com/crypto/v2/",
+			expectedMarketWebsocketURL: "wss://proxy.example.com/crypto/v2/market",
+			expectedUserWebsocketURL:   cdcexchange.UATSandboxUserWebsocketURL,
 		},
 		{
 			name: "successfully creates client with custom http client",
@@ -118,7 +360,9 @@ func TestNew_Success(t *testing.T) {
 				httpClient: &http.Client{Timeout: time.Minute},
 				opts:       []cdcexchange.ClientOption{cdcexchange.WithHTTPClient(&http.Client{Timeout: time.Minute})},
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 	}
 	for _, tt := range tests {
@@ -130,6 +374,8 @@ func TestNew_Success(t *testing.T) {
 			assert.Equal(t, tt.apiKey, client.APIKey())
 			assert.Equal(t, tt.secretKey, client.SecretKey())
 			assert.Equal(t, tt.expectedBaseURL, client.BaseURL())
+			assert.Equal(t, tt.expectedMarketWebsocketURL, client.MarketWebsocketURL())
+			assert.Equal(t, tt.expectedUserWebsocketURL, client.UserWebsocketURL())
 
 			if tt.httpClient == nil {
 				assert.Equal(t, http.DefaultClient, client.HTTPClient())
@@ -189,7 +435,9 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 	tests := []struct {
 		name string
 		args
-		expectedBaseURL string
+		expectedBaseURL            string
+		expectedMarketWebsocketURL string
+		expectedUserWebsocketURL   string
 	}{
 		{
 			name: "successfully updates UAT client",
@@ -198,7 +446,9 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 				secretKey: "secret key",
 				opts:      []cdcexchange.ClientOption{cdcexchange.WithUATEnvironment()},
 			},
-			expectedBaseURL: cdcexchange.UATSandboxBaseURL,
+			expectedBaseURL:            cdcexchange.UATSandboxBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.UATSandboxMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.UATSandboxUserWebsocketURL,
 		},
 		{
 			name: "successfully updates production client",
@@ -207,7 +457,9 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 				secretKey: "secret key",
 				opts:      []cdcexchange.ClientOption{cdcexchange.WithProductionEnvironment()},
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 		{
 			name: "successfully updates production client",
@@ -215,7 +467,9 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 				apiKey:    "api key",
 				secretKey: "secret key",
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 		{
 			name: "successfully updates http client",
@@ -225,7 +479,9 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 				httpClient: &http.Client{Timeout: time.Minute},
 				opts:       []cdcexchange.ClientOption{cdcexchange.WithHTTPClient(&http.Client{Timeout: time.Minute})},
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 	}
 	for _, tt := range tests {
@@ -240,6 +496,8 @@ func TestClient_UpdateConfig_Success(t *testing.T) {
 			assert.Equal(t, tt.apiKey, client.APIKey())
 			assert.Equal(t, tt.secretKey, client.SecretKey())
 			assert.Equal(t, tt.expectedBaseURL, client.BaseURL())
+			assert.Equal(t, tt.expectedMarketWebsocketURL, client.MarketWebsocketURL())
+			assert.Equal(t, tt.expectedUserWebsocketURL, client.UserWebsocketURL())
 
 			if tt.httpClient != nil {
 				assert.Equal(t, tt.httpClient, client.HTTPClient())
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -53,6 +56,9 @@ type (
 	// TAKE_PROFIT 	  	 | BUY  | notional, trigger_price
 	// TAKE_PROFIT 	  	 | SELL | quantity, trigger_price
 	// ------------------+------+-----------------------------------------
+	//
+	// Requests are validated against this table before they are sent, parameters which do not apply
+	// to the order type & side must be omitted. TimeInForce & ExecInst apply to LIMIT orders only.
 	CreateOrderRequest struct {
 		// InstrumentName represents the currency pair to trade (e.g. ETH_CRO or BTC_USDT).
 		InstrumentName string `json:"instrument_name"`
@@ -62,13 +68,13 @@ type (
 		Type OrderType `json:"type"`
 		// Price determines the price of which the trade should be executed.
 		// For LIMIT and STOP_LIMIT orders only.
-		Price float64 `json:"price"`
+		Price decimal.Decimal `json:"price"`
 		// Quantity is the quantity to be sold
 		// For LIMIT, MARKET, STOP_LOSS, TAKE_PROFIT orders only.
-		Quantity float64 `json:"quantity"`
+		Quantity decimal.Decimal `json:"quantity"`
 		// Notional is the amount to spend.
 		// For MARKET (BUY), STOP_LOSS (BUY), TAKE_PROFIT (BUY) orders only.
-		Notional float64 `json:"notional"`
+		Notional decimal.Decimal `json:"notional"`
 		// ClientOID is the optional Client order ID.
 		ClientOID string `json:"client_oid"`
 		// TimeInForce represents how long the order should be active before being cancelled.
@@ -83,7 +89,7 @@ type (
 		ExecInst ExecInst `json:"exec_inst"`
 		// TriggerPrice is the price at which the order is triggered.
 		// Used with STOP_LOSS, STOP_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
-		TriggerPrice float64 `json:"trigger_price"`
+		TriggerPrice decimal.Decimal `json:"trigger_price"`
 	}
 
 	// CreateOrderResponse is the base response returned from the private/create-order API.
@@ -111,9 +117,23 @@ type (
 //
 // Method: private/create-order
 func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
+	return c.createOrder(ctx, methodCreateOrder, req)
+}
+
+// createOrder creates an order via the specified create-order method (spot or margin).
+func (c *client) createOrder(ctx context.Context, method string, req CreateOrderRequest) (*CreateOrderResult, error) {
+	if err := validateCreateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	req, err := c.fitPrecision(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -126,13 +146,13 @@ func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 	if req.Type != "" {
 		params["type"] = req.Type
 	}
-	if req.Price != 0 {
+	if !req.Price.IsZero() {
 		params["price"] = req.Price
 	}
-	if req.Quantity != 0 {
+	if !req.Quantity.IsZero() {
 		params["quantity"] = req.Quantity
 	}
-	if req.Notional != 0 {
+	if !req.Notional.IsZero() {
 		params["notional"] = req.Notional
 	}
 	if req.ClientOID != "" {
@@ -144,7 +164,7 @@ func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 	if req.ExecInst != "" {
 		params["exec_inst"] = req.ExecInst
 	}
-	if req.TriggerPrice != 0 {
+	if !req.TriggerPrice.IsZero() {
 		params["trigger_price"] = req.TriggerPrice
 	}
 
@@ -152,7 +172,7 @@ func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodCreateOrder,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -162,15 +182,21 @@ func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodCreateOrder,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
 		APIKey:    c.apiKey,
 	}
 
+	// without a client order ID, a retried request could create a duplicate order.
+	requester := c.requester
+	if req.ClientOID == "" {
+		requester = requester.WithoutRetries()
+	}
+
 	var createOrderResponse CreateOrderResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCreateOrder, &createOrderResponse)
+	statusCode, err := requester.Post(ctx, body, method, &createOrderResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -181,3 +207,87 @@ func (c *client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 
 	return &createOrderResponse.Result, nil
 }
+
+// validateCreateOrderRequest checks that the mandatory parameters of the order type & side are set,
+// and that no parameters are set which do not apply to the order type & side.
+func validateCreateOrderRequest(req CreateOrderRequest) error {
+	switch {
+	case req.InstrumentName == "":
+		return errors.InvalidParameterError{Parameter: "req.InstrumentName", Reason: "cannot be empty"}
+	case req.Side != OrderSideBuy && req.Side != OrderSideSell:
+		return errors.InvalidParameterError{Parameter: "req.Side", Reason: "must be BUY or SELL"}
+	}
+
+	order := fmt.Sprintf("%s %s orders", req.Type, req.Side)
+
+	var price, quantity, notional, triggerPrice bool
+	switch req.Type {
+	case OrderTypeLimit:
+		price, quantity = true, true
+	case OrderTypeMarket:
+		if req.Side == OrderSideSell {
+			quantity = true
+			break
+		}
+
+		// MARKET BUY orders are for either a notional amount or a quantity.
+		switch {
+		case !req.Notional.IsZero() && !req.Quantity.IsZero():
+			return errors.InvalidParameterError{Parameter: "req.Notional", Reason: "cannot be set with req.Quantity for " + order}
+		case req.Notional.IsZero() && req.Quantity.IsZero():
+			return errors.InvalidParameterError{Parameter: "req.Notional", Reason: "or req.Quantity must be set for " + order}
+		}
+		notional, quantity = !req.Notional.IsZero(), !req.Quantity.IsZero()
+	case OrderTypeStopLimit, OrderTypeTakeProfitLimit:
+		price, quantity, triggerPrice = true, true, true
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		triggerPrice = true
+		if req.Side == OrderSideBuy {
+			notional = true
+		} else {
+			quantity = true
+		}
+	default:
+		return errors.InvalidParameterError{Parameter: "req.Type", Reason: "is not a valid order type"}
+	}
+
+	for _, field := range []struct {
+		parameter string
+		value     decimal.Decimal
+		mandatory bool
+	}{
+		{parameter: "req.Price", value: req.Price, mandatory: price},
+		{parameter: "req.Quantity", value: req.Quantity, mandatory: quantity},
+		{parameter: "req.Notional", value: req.Notional, mandatory: notional},
+		{parameter: "req.TriggerPrice", value: req.TriggerPrice, mandatory: triggerPrice},
+	} {
+		switch {
+		case field.mandatory && field.value.Sign() <= 0:
+			return errors.InvalidParameterError{Parameter: field.parameter, Reason: "must be greater than 0 for " + order}
+		case !field.mandatory && !field.value.IsZero():
+			return errors.InvalidParameterError{Parameter: field.parameter, Reason: "cannot be set for " + order}
+		}
+	}
+
+	switch req.TimeInForce {
+	case "":
+	case TimeInForceGoodTilCancelled, TimeInForceFillOrKill, TimeInForceImmediateOrCancel:
+		if req.Type != OrderTypeLimit {
+			return errors.InvalidParameterError{Parameter: "req.TimeInForce", Reason: "cannot be set for " + order}
+		}
+	default:
+		return errors.InvalidParameterError{Parameter: "req.TimeInForce", Reason: "is not a valid time in force"}
+	}
+
+	switch req.ExecInst {
+	case "":
+	case ExecInstPostOnly:
+		if req.Type != OrderTypeLimit {
+			return errors.InvalidParameterError{Parameter: "req.ExecInst", Reason: "cannot be set for " + order}
+		}
+	default:
+		return errors.InvalidParameterError{Parameter: "req.ExecInst", Reason: "is not a valid exec inst"}
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
@@ -28,23 +29,340 @@ func TestClient_CreateOrder_Error(t *testing.T) {
 		apiKey    = "some api key"
 		secretKey = "some secret key"
 		id        = int64(1234)
+
+		instrument = "some instrument"
 	)
 	testErr := errors.New("some error")
+	d := decimal.MustParse
+
+	validReq := cdcexchange.CreateOrderRequest{
+		InstrumentName: instrument,
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeMarket,
+		Notional:       d("100"),
+	}
 
+	type args struct {
+		req cdcexchange.CreateOrderRequest
+	}
 	tests := []struct {
-		name         string
+		name string
+		args
 		client       http.Client
 		signatureErr error
-		responseErr  error
 		expectedErr  error
 	}{
+		{
+			name: "returns error when instrument name is empty",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					Side:     cdcexchange.OrderSideBuy,
+					Type:     cdcexchange.OrderTypeLimit,
+					Price:    d("1"),
+					Quantity: d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.InstrumentName",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when side is invalid",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           "some side",
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Side",
+				Reason:    "must be BUY or SELL",
+			},
+		},
+		{
+			name: "returns error when type is invalid",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           "some type",
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Type",
+				Reason:    "is not a valid order type",
+			},
+		},
+		{
+			name: "returns error when price is missing for a LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeLimit,
+					Quantity:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Price",
+				Reason:    "must be greater than 0 for LIMIT BUY orders",
+			},
+		},
+		{
+			name: "returns error when quantity is negative for a LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          d("1"),
+					Quantity:       d("-1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Quantity",
+				Reason:    "must be greater than 0 for LIMIT SELL orders",
+			},
+		},
+		{
+			name: "returns error when trigger price is set for a LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+					TriggerPrice:   d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.TriggerPrice",
+				Reason:    "cannot be set for LIMIT BUY orders",
+			},
+		},
+		{
+			name: "returns error when notional & quantity are both set for a MARKET BUY order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeMarket,
+					Quantity:       d("1"),
+					Notional:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Notional",
+				Reason:    "cannot be set with req.Quantity for MARKET BUY orders",
+			},
+		},
+		{
+			name: "returns error when neither notional or quantity are set for a MARKET BUY order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeMarket,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Notional",
+				Reason:    "or req.Quantity must be set for MARKET BUY orders",
+			},
+		},
+		{
+			name: "returns error when price is set for a MARKET BUY order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeMarket,
+					Price:          d("1"),
+					Notional:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Price",
+				Reason:    "cannot be set for MARKET BUY orders",
+			},
+		},
+		{
+			name: "returns error when notional is set for a MARKET SELL order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Type:           cdcexchange.OrderTypeMarket,
+					Quantity:       d("1"),
+					Notional:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Notional",
+				Reason:    "cannot be set for MARKET SELL orders",
+			},
+		},
+		{
+			name: "returns error when trigger price is missing for a STOP_LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeStopLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.TriggerPrice",
+				Reason:    "must be greater than 0 for STOP_LIMIT BUY orders",
+			},
+		},
+		{
+			name: "returns error when price is missing for a TAKE_PROFIT_LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Type:           cdcexchange.OrderTypeTakeProfitLimit,
+					Quantity:       d("1"),
+					TriggerPrice:   d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Price",
+				Reason:    "must be greater than 0 for TAKE_PROFIT_LIMIT SELL orders",
+			},
+		},
+		{
+			name: "returns error when notional is missing for a STOP_LOSS BUY order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeStopLoss,
+					TriggerPrice:   d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Notional",
+				Reason:    "must be greater than 0 for STOP_LOSS BUY orders",
+			},
+		},
+		{
+			name: "returns error when quantity is set for a STOP_LOSS BUY order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeStopLoss,
+					Quantity:       d("1"),
+					Notional:       d("1"),
+					TriggerPrice:   d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Quantity",
+				Reason:    "cannot be set for STOP_LOSS BUY orders",
+			},
+		},
+		{
+			name: "returns error when quantity is missing for a TAKE_PROFIT SELL order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Type:           cdcexchange.OrderTypeTakeProfit,
+					TriggerPrice:   d("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Quantity",
+				Reason:    "must be greater than 0 for TAKE_PROFIT SELL orders",
+			},
+		},
+		{
+			name: "returns error when time in force is set for a MARKET order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Type:           cdcexchange.OrderTypeMarket,
+					Quantity:       d("1"),
+					TimeInForce:    cdcexchange.TimeInForceFillOrKill,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.TimeInForce",
+				Reason:    "cannot be set for MARKET SELL orders",
+			},
+		},
+		{
+			name: "returns error when time in force is invalid",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+					TimeInForce:    "some time in force",
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.TimeInForce",
+				Reason:    "is not a valid time in force",
+			},
+		},
+		{
+			name: "returns error when exec inst is set for a STOP_LIMIT order",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeStopLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+					TriggerPrice:   d("1"),
+					ExecInst:       cdcexchange.ExecInstPostOnly,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.ExecInst",
+				Reason:    "cannot be set for STOP_LIMIT BUY orders",
+			},
+		},
+		{
+			name: "returns error when exec inst is invalid",
+			args: args{
+				req: cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          d("1"),
+					Quantity:       d("1"),
+					ExecInst:       "some exec inst",
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.ExecInst",
+				Reason:    "is not a valid exec inst",
+			},
+		},
 		{
 			name:         "returns error given error generating signature",
+			args:         args{req: validReq},
 			signatureErr: testErr,
 			expectedErr:  testErr,
 		},
 		{
 			name: "returns error given error making request",
+			args: args{req: validReq},
 			client: http.Client{
 				Transport: roundTripper{
 					err: testErr,
@@ -54,6 +372,7 @@ func TestClient_CreateOrder_Error(t *testing.T) {
 		},
 		{
 			name: "returns error given error response",
+			args: args{req: validReq},
 			client: http.Client{
 				Transport: roundTripper{
 					statusCode: http.StatusTeapot,
@@ -62,7 +381,6 @@ func TestClient_CreateOrder_Error(t *testing.T) {
 					},
 				},
 			},
-			responseErr: nil,
 			expectedErr: cdcerrors.ResponseError{
 				Code:           10003,
 				HTTPStatusCode: http.StatusTeapot,
@@ -90,17 +408,24 @@ func TestClient_CreateOrder_Error(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			idGenerator.EXPECT().Generate().Return(id)
-			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
-				APIKey:    apiKey,
-				SecretKey: secretKey,
-				ID:        id,
-				Method:    cdcexchange.MethodCreateOrder,
-				Timestamp: now.UnixMilli(),
-				Params:    map[string]interface{}{},
-			}).Return("signature", tt.signatureErr)
+			if tt.req == validReq {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCreateOrder,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"instrument_name": validReq.InstrumentName,
+						"side":            validReq.Side,
+						"type":            validReq.Type,
+						"notional":        validReq.Notional,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
 
-			res, err := client.CreateOrder(ctx, cdcexchange.CreateOrderRequest{})
+			res, err := client.CreateOrder(ctx, tt.req)
 			require.Error(t, err)
 
 			assert.Empty(t, res)
@@ -129,20 +454,20 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 		id        = int64(1234)
 		signature = "some signature"
 
-		instrument   = "some instrument"
-		orderSide    = cdcexchange.OrderSideBuy
-		orderType    = cdcexchange.OrderTypeMarket
-		price        = 1.234
-		quantity     = 5.678
-		notional     = 9.012
-		clientOID    = "some client oid"
-		timeInForce  = cdcexchange.TimeInForceGoodTilCancelled
-		execInst     = cdcexchange.ExecInstPostOnly
-		triggerPrice = 3.456
+		instrument  = "some instrument"
+		orderSide   = cdcexchange.OrderSideBuy
+		orderType   = cdcexchange.OrderTypeLimit
+		clientOID   = "some client oid"
+		timeInForce = cdcexchange.TimeInForceGoodTilCancelled
+		execInst    = cdcexchange.ExecInstPostOnly
 
 		orderID = "5678"
 	)
-	now := time.Now()
+	var (
+		now      = time.Now()
+		price    = decimal.MustParse("1.234")
+		quantity = decimal.MustParse("5.678")
+	)
 
 	type args struct {
 		req cdcexchange.CreateOrderRequest
@@ -163,19 +488,21 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 					Type:           orderType,
 					Price:          price,
 					Quantity:       quantity,
-					Notional:       notional,
 					ClientOID:      clientOID,
 					TimeInForce:    timeInForce,
 					ExecInst:       execInst,
-					TriggerPrice:   triggerPrice,
 				},
 			},
 			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
 				assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateOrder)
 				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
 
+				// numbers are decoded as json.Number, so that the exact digits sent can be checked.
+				decoder := json.NewDecoder(r.Body)
+				decoder.UseNumber()
+
 				var body api.Request
-				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.NoError(t, decoder.Decode(&body))
 
 				assert.Equal(t, cdcexchange.MethodCreateOrder, body.Method)
 				assert.Equal(t, id, body.ID)
@@ -185,13 +512,11 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 				assert.Equal(t, instrument, body.Params["instrument_name"])
 				assert.Equal(t, string(orderSide), body.Params["side"])
 				assert.Equal(t, string(orderType), body.Params["type"])
-				assert.Equal(t, price, body.Params["price"])
-				assert.Equal(t, quantity, body.Params["quantity"])
-				assert.Equal(t, notional, body.Params["notional"])
+				assert.Equal(t, json.Number(price.String()), body.Params["price"])
+				assert.Equal(t, json.Number(quantity.String()), body.Params["quantity"])
 				assert.Equal(t, clientOID, body.Params["client_oid"])
 				assert.Equal(t, string(timeInForce), body.Params["time_in_force"])
 				assert.Equal(t, string(execInst), body.Params["exec_inst"])
-				assert.Equal(t, triggerPrice, body.Params["trigger_price"])
 
 				res := cdcexchange.CreateOrderResponse{
 					BaseResponse: api.BaseResponse{},
@@ -209,11 +534,9 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 				"type":            orderType,
 				"price":           price,
 				"quantity":        quantity,
-				"notional":        notional,
 				"client_oid":      clientOID,
 				"time_in_force":   timeInForce,
 				"exec_inst":       execInst,
-				"trigger_price":   triggerPrice,
 			},
 			expectedResult: cdcexchange.CreateOrderResult{
 				ClientOID: clientOID,
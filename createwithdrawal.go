@@ -0,0 +1,143 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodCreateWithdrawal = "private/create-withdrawal"
+)
+
+type (
+	// CreateWithdrawalRequest is the request params sent for the private/create-withdrawal API.
+	CreateWithdrawalRequest struct {
+		// ClientWID is the optional client withdrawal ID.
+		// If a withdrawal with the same ClientWID has already been created, the request is rejected,
+		// so it can be used to safely retry a withdrawal request.
+		ClientWID string `json:"client_wid"`
+		// Currency is the currency to withdraw (e.g. BTC).
+		Currency string `json:"currency"`
+		// Amount is the amount to withdraw.
+		Amount decimal.Decimal `json:"amount"`
+		// Address is the address to withdraw to.
+		// The address must be whitelisted in the Crypto.com Exchange.
+		Address string `json:"address"`
+		// AddressTag is the secondary address identifier for coins like XRP, XLM etc. (also known as memo or tag).
+		AddressTag string `json:"address_tag"`
+		// NetworkID is the network to withdraw on (e.g. ETH, BSC).
+		// if NetworkID is omitted, the default network of the currency is used.
+		NetworkID string `json:"network_id"`
+	}
+
+	// CreateWithdrawalResponse is the base response returned from the private/create-withdrawal API.
+	CreateWithdrawalResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result CreateWithdrawalResult `json:"result"`
+	}
+
+	// CreateWithdrawalResult is the result returned from the private/create-withdrawal API.
+	CreateWithdrawalResult struct {
+		// ID is the newly created withdrawal ID.
+		ID int64 `json:"id"`
+		// Amount is the amount withdrawn.
+		Amount decimal.Decimal `json:"amount"`
+		// Fee is the fee charged for the withdrawal.
+		Fee decimal.Decimal `json:"fee"`
+		// Symbol is the currency withdrawn (e.g. BTC).
+		Symbol string `json:"symbol"`
+		// Address is the address withdrawn to.
+		Address string `json:"address"`
+		// ClientWID is the client withdrawal ID (if provided in the request).
+		ClientWID string `json:"client_wid"`
+		// CreateTime is the time the withdrawal was created.
+		CreateTime cdctime.Time `json:"create_time"`
+	}
+)
+
+// CreateWithdrawal creates a withdrawal request.
+//
+// Withdrawal setting must be enabled for your API Key, and the withdrawal address must be whitelisted.
+//
+// req.ClientWID is optional, but recommended so that a failed request can be retried without
+// risking a duplicate withdrawal.
+//
+// Method: private/create-withdrawal
+func (c *client) CreateWithdrawal(ctx context.Context, req CreateWithdrawalRequest) (*CreateWithdrawalResult, error) {
+	switch {
+	case req.Currency == "":
+		return nil, errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	case req.Amount.Sign() <= 0:
+		return nil, errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	case req.Address == "":
+		return nil, errors.InvalidParameterError{Parameter: "req.Address", Reason: "cannot be empty"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = req.Currency
+	params["amount"] = req.Amount
+	params["address"] = req.Address
+
+	if req.ClientWID != "" {
+		params["client_wid"] = req.ClientWID
+	}
+	if req.AddressTag != "" {
+		params["address_tag"] = req.AddressTag
+	}
+	if req.NetworkID != "" {
+		params["network_id"] = req.NetworkID
+	}
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodCreateWithdrawal,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodCreateWithdrawal,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	// the Exchange only rejects a repeated withdrawal if it has a client withdrawal ID.
+	requester := c.requester
+	if req.ClientWID == "" {
+		requester = requester.WithoutRetries()
+	}
+
+	var createWithdrawalResponse CreateWithdrawalResponse
+	statusCode, err := requester.Post(ctx, body, methodCreateWithdrawal, &createWithdrawalResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, createWithdrawalResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return &createWithdrawalResponse.Result, nil
+}
@@ -0,0 +1,330 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_CreateWithdrawal_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	validReq := cdcexchange.CreateWithdrawalRequest{
+		Currency: "BTC",
+		Amount:   decimal.MustParse("1"),
+		Address:  "some address",
+	}
+
+	type args struct {
+		req cdcexchange.CreateWithdrawalRequest
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Amount:  decimal.MustParse("1"),
+					Address: "some address",
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when amount is 0",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: "BTC",
+					Address:  "some address",
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "returns error when address is empty",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: "BTC",
+					Amount:   decimal.MustParse("1"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Address",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         args{req: validReq},
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: args{req: validReq},
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: args{req: validReq},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			if tt.req == validReq {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCreateWithdrawal,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": validReq.Currency,
+						"amount":   validReq.Amount,
+						"address":  validReq.Address,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.CreateWithdrawal(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Nil(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_CreateWithdrawal_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name           string
+		req            cdcexchange.CreateWithdrawalRequest
+		expectedParams map[string]interface{}
+		expectedResult *cdcexchange.CreateWithdrawalResult
+	}{
+		{
+			name: "successfully creates a withdrawal with required params",
+			req: cdcexchange.CreateWithdrawalRequest{
+				Currency: "BTC",
+				Amount:   decimal.MustParse("1"),
+				Address:  "some address",
+			},
+			expectedParams: map[string]interface{}{
+				"currency": "BTC",
+				"amount":   decimal.MustParse("1"),
+				"address":  "some address",
+			},
+			expectedResult: &cdcexchange.CreateWithdrawalResult{
+				ID:         2220,
+				Amount:     decimal.MustParse("1"),
+				Fee:        decimal.MustParse("0.0004"),
+				Symbol:     "BTC",
+				Address:    "some address",
+				CreateTime: cdctime.Time(now),
+			},
+		},
+		{
+			name: "successfully creates a withdrawal with all params",
+			req: cdcexchange.CreateWithdrawalRequest{
+				ClientWID:  "some client wid",
+				Currency:   "XRP",
+				Amount:     decimal.MustParse("1"),
+				Address:    "some address",
+				AddressTag: "some address tag",
+				NetworkID:  "XRP",
+			},
+			expectedParams: map[string]interface{}{
+				"client_wid":  "some client wid",
+				"currency":    "XRP",
+				"amount":      decimal.MustParse("1"),
+				"address":     "some address",
+				"address_tag": "some address tag",
+				"network_id":  "XRP",
+			},
+			expectedResult: &cdcexchange.CreateWithdrawalResult{
+				ID:         2220,
+				Amount:     decimal.MustParse("1"),
+				Fee:        decimal.MustParse("0.0004"),
+				Symbol:     "XRP",
+				Address:    "some address",
+				ClientWID:  "some client wid",
+				CreateTime: cdctime.Time(now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateWithdrawal)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodCreateWithdrawal, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+
+				// params are compared as JSON, as decimals are decoded as float64.
+				expectedParams, err := json.Marshal(tt.expectedParams)
+				require.NoError(t, err)
+				params, err := json.Marshal(body.Params)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(expectedParams), string(params))
+
+				_, err = w.Write([]byte(fmt.Sprintf(`{
+					"id": 0,
+					"method": "",
+					"code": 0,
+					"result": {
+						"id": %d,
+						"amount": %v,
+						"fee": %v,
+						"symbol": %q,
+						"address": %q,
+						"client_wid": %q,
+						"create_time": %d
+					}
+				}`,
+					tt.expectedResult.ID,
+					tt.expectedResult.Amount,
+					tt.expectedResult.Fee,
+					tt.expectedResult.Symbol,
+					tt.expectedResult.Address,
+					tt.expectedResult.ClientWID,
+					now.UnixMilli(),
+				)))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodCreateWithdrawal,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			res, err := client.CreateWithdrawal(ctx, tt.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, res)
+		})
+	}
+}
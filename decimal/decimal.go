@@ -0,0 +1,362 @@
+// Package decimal provides an exact decimal number type for prices, quantities & amounts,
+// avoiding the rounding errors of float64.
+package decimal
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxScale bounds the exponent of a parsed decimal & the number of digits it has after the decimal point,
+// so that a number such as "1e2000000000" is rejected rather than exhausting memory.
+const maxScale = 1000
+
+// Zero is the decimal 0, equal to the zero value of Decimal.
+var Zero = Decimal{}
+
+// Decimal is an exact, arbitrary precision decimal number. The zero value is 0.
+//
+// Decimals are held in a canonical form, so equal values can be compared with == and used as map keys.
+type Decimal struct {
+	// s is the canonical representation of the number, without trailing zeros in the fraction.
+	// 0 is represented by an empty string.
+	s string
+}
+
+// New returns the decimal coefficient * 10^exponent.
+func New(coefficient int64, exponent int32) Decimal {
+	return fromBig(big.NewInt(coefficient), -exponent)
+}
+
+// NewFromInt returns the decimal representation of i.
+func NewFromInt(i int64) Decimal {
+	return New(i, 0)
+}
+
+// NewFromFloat returns the decimal representation of f, using the fewest digits which represent f exactly
+// (e.g. 0.1 rather than 0.1000000000000000055511151231257827).
+//
+// NewFromFloat panics if f is NaN or infinite.
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("decimal: cannot create decimal from %v", f))
+	}
+
+	return MustParse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Parse parses a decimal from a string such as "123.45", "-0.001" or "1e-8".
+//
+// Numbers with an exponent beyond ±1000, or more than 1000 digits after the decimal point, cannot be parsed.
+func Parse(s string) (Decimal, error) {
+	str := s
+
+	var exponent int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("decimal: invalid exponent in %q", s)
+		}
+		if exp < -maxScale || exp > maxScale {
+			return Decimal{}, fmt.Errorf("decimal: exponent out of range in %q", s)
+		}
+		exponent = exp
+		str = str[:i]
+	}
+
+	negative := false
+	switch {
+	case strings.HasPrefix(str, "-"):
+		negative = true
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	integer, fraction := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		integer, fraction = str[:i], str[i+1:]
+	}
+
+	digits := integer + fraction
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", s)
+	}
+
+	scale := int64(len(fraction)) - exponent
+	if scale < -maxScale || scale > maxScale {
+		return Decimal{}, fmt.Errorf("decimal: scale out of range in %q", s)
+	}
+
+	coefficient, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		coefficient.Neg(coefficient)
+	}
+
+	return fromBig(coefficient, int32(scale)), nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// String returns the decimal as a string, without an exponent or trailing zeros (e.g. "0.0001").
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+
+	return d.s
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// IntPart returns the integer part of d, truncated towards zero.
+func (d Decimal) IntPart() int64 {
+	coefficient, scale := d.big()
+	return coefficient.Quo(coefficient, pow10(scale)).Int64()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.s == ""
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	switch {
+	case d.s == "":
+		return 0
+	case d.s[0] == '-':
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	if i := strings.IndexByte(d.s, '.'); i >= 0 {
+		return int32(len(d.s) - i - 1)
+	}
+
+	return 0
+}
+
+// Cmp returns -1 if d < d2, 0 if d == d2 and +1 if d > d2.
+func (d Decimal) Cmp(d2 Decimal) int {
+	return d.Sub(d2).Sign()
+}
+
+// Equal reports whether d == d2.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d == d2
+}
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return fromBig(x.Add(x, y), scale)
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return fromBig(x.Sub(x, y), scale)
+}
+
+// Mul returns d * d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	x, xScale := d.big()
+	y, yScale := d2.big()
+
+	return fromBig(x.Mul(x, y), xScale+yScale)
+}
+
+// Div returns d / d2 truncated towards zero to the specified number of decimal places
+// (e.g. 2 / 3 to 4 places is 0.6666). Div panics if d2 is 0.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	x, xScale := d.big()
+	y, yScale := d2.big()
+
+	// d / d2 = (x * 10^(yScale+places)) / (y * 10^xScale) * 10^-places
+	x.Mul(x, pow10(yScale+places))
+	y.Mul(y, pow10(xScale))
+
+	return fromBig(x.Quo(x, y), places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	switch d.Sign() {
+	case 0:
+		return d
+	case -1:
+		return Decimal{s: d.s[1:]}
+	default:
+		return Decimal{s: "-" + d.s}
+	}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+
+	return d
+}
+
+// Round returns d rounded to the specified number of decimal places, with halves rounded away from zero
+// (e.g. 1.235 rounded to 2 places is 1.24).
+func (d Decimal) Round(places int32) Decimal {
+	coefficient, scale := d.big()
+	if scale <= places {
+		return d
+	}
+
+	divisor := pow10(scale - places)
+	quotient, remainder := new(big.Int).QuoRem(coefficient, divisor, new(big.Int))
+
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(coefficient.Sign())))
+	}
+
+	return fromBig(quotient, places)
+}
+
+// Truncate returns d truncated towards zero to the specified number of decimal places
+// (e.g. 1.239 truncated to 2 places is 1.23).
+func (d Decimal) Truncate(places int32) Decimal {
+	coefficient, scale := d.big()
+	if scale <= places {
+		return d
+	}
+
+	return fromBig(coefficient.Quo(coefficient, pow10(scale-places)), places)
+}
+
+// MarshalJSON encodes d as a JSON number with the exact digits of d.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes d from either a JSON number or string, null & "" are decoded as 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		s = string(data[1 : len(data)-1])
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+// big returns the coefficient & scale of d, such that d = coefficient * 10^-scale.
+func (d Decimal) big() (*big.Int, int32) {
+	if d.s == "" {
+		return new(big.Int), 0
+	}
+
+	digits, scale := d.s, int32(0)
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		scale = int32(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+
+	coefficient, _ := new(big.Int).SetString(digits, 10)
+
+	return coefficient, scale
+}
+
+// align returns the coefficients of x & y at the same scale.
+func align(x, y Decimal) (*big.Int, *big.Int, int32) {
+	xCoefficient, xScale := x.big()
+	yCoefficient, yScale := y.big()
+
+	switch {
+	case xScale < yScale:
+		xCoefficient.Mul(xCoefficient, pow10(yScale-xScale))
+		return xCoefficient, yCoefficient, yScale
+	case yScale < xScale:
+		yCoefficient.Mul(yCoefficient, pow10(xScale-yScale))
+		return xCoefficient, yCoefficient, xScale
+	default:
+		return xCoefficient, yCoefficient, xScale
+	}
+}
+
+// fromBig returns the decimal coefficient * 10^-scale in canonical form.
+func fromBig(coefficient *big.Int, scale int32) Decimal {
+	if coefficient.Sign() == 0 {
+		return Decimal{}
+	}
+
+	if scale < 0 {
+		coefficient = new(big.Int).Mul(coefficient, pow10(-scale))
+		scale = 0
+	}
+
+	digits := new(big.Int).Abs(coefficient).String()
+
+	// trailing zeros in the fraction are removed, so that each value has a single representation.
+	for scale > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+
+	if scale > 0 {
+		if pad := int(scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+
+	if coefficient.Sign() < 0 {
+		digits = "-" + digits
+	}
+
+	return Decimal{s: digits}
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,186 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+func TestParse_Error(t *testing.T) {
+	tests := []string{
+		"", "-", ".", "abc", "1.2.3", "1e", "1ex", "0x10", "1,000",
+		// the exponent or scale is out of range.
+		"1e2000000000", "1e-2000000000", "1e1001", "1e-1001", "1e9999999999",
+		"0.5e-1000", "0." + strings.Repeat("1", 1001),
+	}
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			_, err := decimal.Parse(s)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParse_Success(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{s: "0", expected: "0"},
+		{s: "-0.000", expected: "0"},
+		{s: "123", expected: "123"},
+		{s: "+123", expected: "123"},
+		{s: "123.4500", expected: "123.45"},
+		{s: "-0.001", expected: "-0.001"},
+		{s: ".5", expected: "0.5"},
+		{s: "5.", expected: "5"},
+		{s: "1e-8", expected: "0.00000001"},
+		{s: "1.5E3", expected: "1500"},
+		{s: "00012.3400", expected: "12.34"},
+		{s: "123456789012345678901234567890.123456789", expected: "123456789012345678901234567890.123456789"},
+		{s: "1e1000", expected: "1" + strings.Repeat("0", 1000)},
+		{s: "1e-1000", expected: "0." + strings.Repeat("0", 999) + "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			d, err := decimal.Parse(tt.s)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, d.String())
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, "1.2345", decimal.New(12345, -4).String())
+	assert.Equal(t, "12300", decimal.New(123, 2).String())
+	assert.Equal(t, "-7", decimal.NewFromInt(-7).String())
+	assert.Equal(t, "0.1", decimal.NewFromFloat(0.1).String())
+	assert.Equal(t, "0.3", decimal.NewFromFloat(0.3).String())
+	assert.Equal(t, decimal.Zero, decimal.NewFromFloat(0))
+	assert.Equal(t, decimal.Zero, decimal.Decimal{})
+
+	assert.Panics(t, func() { decimal.MustParse("abc") })
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	var (
+		a = decimal.MustParse("0.1")
+		b = decimal.MustParse("0.2")
+	)
+
+	// 0.1 + 0.2 is exactly 0.3, unlike with float64.
+	assert.Equal(t, decimal.MustParse("0.3"), a.Add(b))
+	assert.Equal(t, decimal.MustParse("-0.1"), a.Sub(b))
+	assert.Equal(t, decimal.MustParse("0.02"), a.Mul(b))
+	assert.Equal(t, decimal.MustParse("-0.1"), a.Neg())
+	assert.Equal(t, a, a.Neg().Abs())
+	assert.Equal(t, decimal.Zero, a.Sub(a))
+	assert.Equal(t, decimal.MustParse("1"), decimal.MustParse("0.25").Mul(decimal.NewFromInt(4)))
+	assert.Equal(t, decimal.MustParse("30001.2"), decimal.MustParse("30000.15").Add(decimal.MustParse("1.05")))
+	assert.Equal(t, decimal.MustParse("0.5"), a.Div(b, 8))
+	assert.Equal(t, decimal.MustParse("0.6666"), decimal.NewFromInt(2).Div(decimal.NewFromInt(3), 4))
+	assert.Equal(t, decimal.MustParse("-2000"), decimal.MustParse("-0.2").Div(decimal.MustParse("0.0001"), 2))
+	assert.Panics(t, func() { a.Div(decimal.Zero, 2) })
+}
+
+func TestDecimal_Comparison(t *testing.T) {
+	var (
+		a = decimal.MustParse("1.5")
+		b = decimal.MustParse("1.50")
+		c = decimal.MustParse("-2")
+	)
+
+	assert.True(t, a == b)
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.Equal(t, 1, a.Cmp(c))
+	assert.Equal(t, -1, c.Cmp(a))
+	assert.True(t, c.LessThan(a))
+	assert.True(t, a.GreaterThan(c))
+
+	assert.Equal(t, 1, a.Sign())
+	assert.Equal(t, -1, c.Sign())
+	assert.Equal(t, 0, decimal.Zero.Sign())
+	assert.True(t, decimal.Zero.IsZero())
+	assert.False(t, a.IsZero())
+
+	assert.Equal(t, int32(1), a.Scale())
+	assert.Equal(t, int32(0), c.Scale())
+	assert.Equal(t, 1.5, a.Float64())
+	assert.Equal(t, int64(1), a.IntPart())
+	assert.Equal(t, int64(-2), c.IntPart())
+	assert.Equal(t, int64(0), decimal.MustParse("-0.9").IntPart())
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		d         string
+		places    int32
+		rounded   string
+		truncated string
+	}{
+		{d: "1.234", places: 2, rounded: "1.23", truncated: "1.23"},
+		{d: "1.235", places: 2, rounded: "1.24", truncated: "1.23"},
+		{d: "1.239", places: 2, rounded: "1.24", truncated: "1.23"},
+		{d: "-1.235", places: 2, rounded: "-1.24", truncated: "-1.23"},
+		{d: "1.2", places: 4, rounded: "1.2", truncated: "1.2"},
+		{d: "0.996", places: 2, rounded: "1", truncated: "0.99"},
+		{d: "0.004", places: 2, rounded: "0", truncated: "0"},
+		{d: "0.005", places: 2, rounded: "0.01", truncated: "0"},
+		{d: "1234.5", places: 0, rounded: "1235", truncated: "1234"},
+		{d: "1250", places: -2, rounded: "1300", truncated: "1200"},
+		{d: "0", places: 2, rounded: "0", truncated: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d, func(t *testing.T) {
+			d := decimal.MustParse(tt.d)
+
+			assert.Equal(t, decimal.MustParse(tt.rounded), d.Round(tt.places))
+			assert.Equal(t, decimal.MustParse(tt.truncated), d.Truncate(tt.places))
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type payload struct {
+		Price decimal.Decimal `json:"price"`
+	}
+
+	t.Run("marshals as number with exact digits", func(t *testing.T) {
+		b, err := json.Marshal(payload{Price: decimal.MustParse("0.00000001")})
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"price":0.00000001}`, string(b))
+	})
+
+	tests := []struct {
+		name     string
+		json     string
+		expected decimal.Decimal
+	}{
+		{name: "unmarshals number", json: `{"price":123.456}`, expected: decimal.MustParse("123.456")},
+		{name: "unmarshals exponent", json: `{"price":1E-8}`, expected: decimal.MustParse("0.00000001")},
+		{name: "unmarshals string", json: `{"price":"0.1"}`, expected: decimal.MustParse("0.1")},
+		{name: "unmarshals empty string as zero", json: `{"price":""}`, expected: decimal.Zero},
+		{name: "unmarshals null as zero", json: `{"price":null}`, expected: decimal.Zero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p payload
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &p))
+
+			assert.Equal(t, tt.expected, p.Price)
+		})
+	}
+
+	t.Run("returns error for invalid value", func(t *testing.T) {
+		var p payload
+		require.Error(t, json.Unmarshal([]byte(`{"price":"abc"}`), &p))
+	})
+}
@@ -0,0 +1,102 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodDerivTransfer = "private/deriv/transfer"
+
+	DerivWalletSpot        DerivWallet = "SPOT"
+	DerivWalletDerivatives DerivWallet = "DERIVATIVES"
+)
+
+type (
+	// DerivWallet is a wallet which funds can be transferred to and from (SPOT/DERIVATIVES).
+	DerivWallet string
+
+	// DerivTransferRequest is the request params sent for the private/deriv/transfer API.
+	DerivTransferRequest struct {
+		// Currency is the currency to transfer (e.g. USDT).
+		Currency string `json:"currency"`
+		// From is the wallet to transfer from.
+		From DerivWallet `json:"from"`
+		// To is the wallet to transfer to.
+		To DerivWallet `json:"to"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// DerivTransferResponse is the base response returned from the private/deriv/transfer API.
+	DerivTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// DerivTransfer transfers funds between the spot and derivatives wallets.
+//
+// Method: private/deriv/transfer
+func (c *client) DerivTransfer(ctx context.Context, req DerivTransferRequest) error {
+	switch {
+	case req.Currency == "":
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	case req.From == "":
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "cannot be empty"}
+	case req.To == "":
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be empty"}
+	case req.Amount.Sign() <= 0:
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = req.Currency
+	params["from"] = req.From
+	params["to"] = req.To
+	params["amount"] = req.Amount
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodDerivTransfer,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodDerivTransfer,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var transferResponse DerivTransferResponse
+	statusCode, err := c.requester.WithoutRetries().Post(ctx, body, methodDerivTransfer, &transferResponse)
+	if err != nil {
+		return fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, transferResponse.Code); err != nil {
+		return fmt.Errorf("error received in response: %w", err)
+	}
+
+	return nil
+}
@@ -42,6 +42,10 @@ var (
 	ErrMGBlockedBorrow           = errors.New("borrow has been suspended. please try again later")
 	ErrMGBlockedNewOrder         = errors.New("placing new order has been suspended. please try again later")
 	ErrMGCreditLineNotMaintained = errors.New("please ensure your credit line is maintained and try again later")
+
+	ErrConnectionClosed = errors.New("websocket connection closed")
+	ErrHeartbeatMissed  = errors.New("websocket heartbeat not received within timeout")
+	ErrMessageDropped   = errors.New("websocket message dropped as the subscription is not being consumed")
 )
 
 // InvalidParameterError is returned when a required parameter is passed that is invalid.
@@ -63,7 +67,13 @@ type ResponseError struct {
 
 // Error will return a string representation of the response error in the following format:
 // 401 Unauthorized: (10003) ip address not whitelisted
+//
+// Websocket responses have no HTTP status code, so it is omitted:
+// (10003) ip address not whitelisted
 func (re ResponseError) Error() string {
+	if re.HTTPStatusCode == 0 {
+		return fmt.Sprintf("(%d) %v", re.Code, re.Err)
+	}
 	return fmt.Sprintf("%d %s: (%d) %v", re.HTTPStatusCode, http.StatusText(re.HTTPStatusCode), re.Code, re.Err)
 }
 
@@ -427,3 +427,34 @@ func TestNewResponseError_Success(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseError_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         ResponseError
+		expectedMsg string
+	}{
+		{
+			name: "includes http status when present",
+			err: ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusUnauthorized,
+				Err:            ErrIllegalIP,
+			},
+			expectedMsg: "401 Unauthorized: (10003) ip address not whitelisted",
+		},
+		{
+			name: "omits http status when not present",
+			err: ResponseError{
+				Code: 10003,
+				Err:  ErrIllegalIP,
+			},
+			expectedMsg: "(10003) ip address not whitelisted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMsg, tt.err.Error())
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -30,13 +32,13 @@ type (
 	// Account represents balance details of a specific token.
 	Account struct {
 		// Balance is the total balance (Available + Order + Stake).
-		Balance float64 `json:"balance"`
+		Balance decimal.Decimal `json:"balance"`
 		// Available is the available balance (e.g. not in orders, or locked, etc.).
-		Available float64 `json:"available"`
+		Available decimal.Decimal `json:"available"`
 		// Order is the balance locked in orders.
-		Order float64 `json:"order"`
+		Order decimal.Decimal `json:"order"`
 		// Stake is the balance locked for staking (typically only used for CRO).
-		Stake float64 `json:"stake"`
+		Stake decimal.Decimal `json:"stake"`
 		// Currency is the symbol for the currency (e.g. CRO).
 		Currency string `json:"currency"`
 	}
@@ -50,7 +52,7 @@ type (
 func (c *client) GetAccountSummary(ctx context.Context, currency string) ([]Account, error) {
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -9,6 +9,8 @@ import (
 
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -29,12 +31,16 @@ type (
 	BookResult struct {
 		// Bids is an array of bids.
 		// [0] = Price, [1] = Quantity, [2] = Number of Orders.
-		Bids [][]float64 `json:"bids"`
+		Bids [][]decimal.Decimal `json:"bids"`
 		// Asks is an array of asks.
 		// [0] = Price, [1] = Quantity, [2] = Number of Orders.
-		Asks [][]float64 `json:"asks"`
+		Asks [][]decimal.Decimal `json:"asks"`
 		// Timestamp is the timestamp of the data.
 		Timestamp time.Time `json:"t"`
+		// UpdateID is the sequence number of the data (if provided).
+		UpdateID int64 `json:"u"`
+		// PreviousUpdateID is the sequence number of the previous update, set only for incremental updates.
+		PreviousUpdateID int64 `json:"pu"`
 	}
 )
 
@@ -42,7 +48,12 @@ type (
 //
 // Method: public/get-book
 func (c *client) GetBook(ctx context.Context, instrument string, depth int) (*BookResult, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.requester.BaseURL, methodGetBook), nil)
+	return c.getBook(ctx, c.requester, instrument, depth)
+}
+
+// getBook fetches the public order book for a particular instrument and depth with the specified requester.
+func (c *client) getBook(ctx context.Context, requester api.Requester, instrument string, depth int) (*BookResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", requester.BaseURL, methodGetBook), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -58,7 +69,7 @@ func (c *client) GetBook(ctx context.Context, instrument string, depth int) (*Bo
 
 	req.URL.RawQuery = q.Encode()
 
-	res, err := c.requester.Client.Do(req)
+	res, err := requester.Do(req, methodGetBook)
 	if err != nil {
 		return nil, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -74,7 +85,7 @@ func (c *client) GetBook(ctx context.Context, instrument string, depth int) (*Bo
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
-	if err := c.requester.CheckErrorResponse(res.StatusCode, bookResponse.Code); err != nil {
+	if err := requester.CheckErrorResponse(res.StatusCode, bookResponse.Code); err != nil {
 		return nil, fmt.Errorf("error received in response: %w", err)
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
@@ -150,8 +151,8 @@ func TestClient_GetBook_Success(t *testing.T) {
 				require.NoError(t, err)
 			},
 			expectedResult: cdcexchange.BookResult{
-				Bids:      [][]float64{{9668.44, 0.006325, 1.0}},
-				Asks:      [][]float64{{9697.0, 0.68251, 1.0}},
+				Bids:      [][]decimal.Decimal{{decimal.MustParse("9668.44"), decimal.MustParse("0.006325"), decimal.MustParse("1.0")}},
+				Asks:      [][]decimal.Decimal{{decimal.MustParse("9697.0"), decimal.MustParse("0.68251"), decimal.MustParse("1.0")}},
 				Timestamp: cdctime.Time(now),
 			},
 		},
@@ -0,0 +1,127 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetCandlestick = "public/get-candlestick"
+
+	Interval1Minute   Interval = "1m"
+	Interval5Minutes  Interval = "5m"
+	Interval15Minutes Interval = "15m"
+	Interval30Minutes Interval = "30m"
+	Interval1Hour     Interval = "1h"
+	Interval4Hours    Interval = "4h"
+	Interval6Hours    Interval = "6h"
+	Interval12Hours   Interval = "12h"
+	Interval1Day      Interval = "1D"
+	Interval7Days     Interval = "7D"
+	Interval14Days    Interval = "14D"
+	Interval1Month    Interval = "1M"
+)
+
+type (
+	// Interval is the period of a candlestick (e.g. 1m, 1h, 1D).
+	Interval string
+
+	// CandlestickResponse is the base response returned from the public/get-candlestick API.
+	CandlestickResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result CandlestickResult `json:"result"`
+	}
+
+	// CandlestickResult is the result returned from the public/get-candlestick API.
+	CandlestickResult struct {
+		// InstrumentName is the instrument name (e.g. BTC_USDT, ETH_CRO, etc).
+		InstrumentName string `json:"instrument_name"`
+		// Interval is the period of each candlestick.
+		Interval Interval `json:"interval"`
+		// Data is the returned candlesticks, oldest first.
+		Data []Candlestick `json:"data"`
+	}
+
+	// Candlestick represents the OHLCV data of an instrument over a single interval.
+	Candlestick struct {
+		// Timestamp is the end time of the candlestick.
+		Timestamp time.Time `json:"t"`
+		// Open is the opening price of the interval.
+		Open decimal.Decimal `json:"o"`
+		// High is the highest price of the interval.
+		High decimal.Decimal `json:"h"`
+		// Low is the lowest price of the interval.
+		Low decimal.Decimal `json:"l"`
+		// Close is the closing price of the interval.
+		Close decimal.Decimal `json:"c"`
+		// Volume is the traded volume of the interval.
+		Volume decimal.Decimal `json:"v"`
+	}
+)
+
+// Valid returns true if the interval is one supported by the Exchange.
+func (i Interval) Valid() bool {
+	switch i {
+	case Interval1Minute, Interval5Minutes, Interval15Minutes, Interval30Minutes,
+		Interval1Hour, Interval4Hours, Interval6Hours, Interval12Hours,
+		Interval1Day, Interval7Days, Interval14Days, Interval1Month:
+		return true
+	}
+	return false
+}
+
+// GetCandlesticks fetches the public candlesticks (k-line data history) for a particular instrument and interval.
+//
+// Method: public/get-candlestick
+func (c *client) GetCandlesticks(ctx context.Context, instrument string, interval Interval) ([]Candlestick, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+	if !interval.Valid() {
+		return nil, errors.InvalidParameterError{Parameter: "interval", Reason: "must be a supported interval"}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.requester.BaseURL, methodGetCandlestick), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	q := req.URL.Query()
+	q.Add("instrument_name", instrument)
+	q.Add("timeframe", string(interval))
+	req.URL.RawQuery = q.Encode()
+
+	res, err := c.requester.Do(req, methodGetCandlestick)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var candlestickResponse CandlestickResponse
+	if err := json.Unmarshal(resBytes, &candlestickResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(res.StatusCode, candlestickResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return candlestickResponse.Result.Data, nil
+}
@@ -0,0 +1,236 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_GetCandlesticks_Error(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		instrument = "some instrument"
+	)
+	testErr := errors.New("some error")
+
+	type args struct {
+		instrument string
+		interval   cdcexchange.Interval
+	}
+	tests := []struct {
+		name string
+		args
+		client      http.Client
+		expectedErr error
+	}{
+		{
+			name: "returns error when instrument is empty",
+			args: args{
+				interval: cdcexchange.Interval1Hour,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "instrument",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when interval is empty",
+			args: args{
+				instrument: instrument,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "interval",
+				Reason:    "must be a supported interval",
+			},
+		},
+		{
+			name: "returns error when interval is not supported",
+			args: args{
+				instrument: instrument,
+				interval:   "2h",
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "interval",
+				Reason:    "must be a supported interval",
+			},
+		},
+		{
+			name: "returns error given error making request",
+			args: args{
+				instrument: instrument,
+				interval:   cdcexchange.Interval1Hour,
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: args{
+				instrument: instrument,
+				interval:   cdcexchange.Interval1Hour,
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				now   = time.Now()
+				clock = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+			)
+			require.NoError(t, err)
+
+			candlesticks, err := client.GetCandlesticks(ctx, tt.instrument, tt.interval)
+			require.Error(t, err)
+
+			assert.Empty(t, candlesticks)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetCandlesticks_Success(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name     string
+		interval cdcexchange.Interval
+	}{
+		{name: "returns 1 minute candlesticks", interval: cdcexchange.Interval1Minute},
+		{name: "returns 5 minute candlesticks", interval: cdcexchange.Interval5Minutes},
+		{name: "returns 15 minute candlesticks", interval: cdcexchange.Interval15Minutes},
+		{name: "returns 30 minute candlesticks", interval: cdcexchange.Interval30Minutes},
+		{name: "returns 1 hour candlesticks", interval: cdcexchange.Interval1Hour},
+		{name: "returns 4 hour candlesticks", interval: cdcexchange.Interval4Hours},
+		{name: "returns 6 hour candlesticks", interval: cdcexchange.Interval6Hours},
+		{name: "returns 12 hour candlesticks", interval: cdcexchange.Interval12Hours},
+		{name: "returns 1 day candlesticks", interval: cdcexchange.Interval1Day},
+		{name: "returns 7 day candlesticks", interval: cdcexchange.Interval7Days},
+		{name: "returns 14 day candlesticks", interval: cdcexchange.Interval14Days},
+		{name: "returns 1 month candlesticks", interval: cdcexchange.Interval1Month},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				clock = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetCandlestick)
+				assert.Equal(t, http.MethodGet, r.Method)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				assert.Equal(t, instrument, r.URL.Query().Get("instrument_name"))
+				assert.Equal(t, string(tt.interval), r.URL.Query().Get("timeframe"))
+
+				res := fmt.Sprintf(`{
+					"id": 0,
+					"method": "public/get-candlestick",
+					"code": 0,
+					"result": {
+						"instrument_name": %q,
+						"interval": %q,
+						"data": [
+							{"t": %d, "o": 162.03, "h": 161.96, "l": 161.98, "c": 161.97, "v": 336.452694},
+							{"t": %d, "o": 161.97, "h": 162.5, "l": 161.9, "c": 162.4, "v": 120.5}
+						]
+					}
+				}`, instrument, tt.interval, now.UnixMilli(), now.Add(time.Minute).UnixMilli())
+
+				_, err := w.Write([]byte(res))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			)
+			require.NoError(t, err)
+
+			res, err := client.GetCandlesticks(ctx, instrument, tt.interval)
+			require.NoError(t, err)
+
+			assert.Equal(t, []cdcexchange.Candlestick{
+				{
+					Timestamp: cdctime.Time(now),
+					Open:      decimal.MustParse("162.03"),
+					High:      decimal.MustParse("161.96"),
+					Low:       decimal.MustParse("161.98"),
+					Close:     decimal.MustParse("161.97"),
+					Volume:    decimal.MustParse("336.452694"),
+				},
+				{
+					Timestamp: cdctime.Time(now.Add(time.Minute)),
+					Open:      decimal.MustParse("161.97"),
+					High:      decimal.MustParse("162.5"),
+					Low:       decimal.MustParse("161.9"),
+					Close:     decimal.MustParse("162.4"),
+					Volume:    decimal.MustParse("120.5"),
+				},
+			}, res)
+		})
+	}
+}
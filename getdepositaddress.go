@@ -0,0 +1,105 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+const (
+	methodGetDepositAddress = "private/get-deposit-address"
+
+	DepositAddressStatusInactive DepositAddressStatus = "0"
+	DepositAddressStatusActive   DepositAddressStatus = "1"
+)
+
+type (
+	// DepositAddressStatus is the status of a deposit address.
+	DepositAddressStatus string
+
+	// GetDepositAddressResponse is the base response returned from the private/get-deposit-address API.
+	GetDepositAddressResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetDepositAddressResult `json:"result"`
+	}
+
+	// GetDepositAddressResult is the result returned from the private/get-deposit-address API.
+	GetDepositAddressResult struct {
+		// DepositAddressList is the array of deposit addresses.
+		DepositAddressList []DepositAddress `json:"deposit_address_list"`
+	}
+
+	// DepositAddress represents the details of a deposit address.
+	DepositAddress struct {
+		// ID is the unique identifier of the deposit address.
+		ID string `json:"id"`
+		// Currency is the currency of the deposit address (e.g. BTC).
+		Currency string `json:"currency"`
+		// Network is the network of the deposit address (e.g. ETH, BSC).
+		Network string `json:"network"`
+		// Address is the deposit address, including the address tag if applicable (e.g. XRP_ADDRESS?123).
+		Address string `json:"address"`
+		// CreateTime is the time the deposit address was created.
+		CreateTime cdctime.Time `json:"create_time"`
+		// Status is the status of the deposit address.
+		Status DepositAddressStatus `json:"status"`
+	}
+)
+
+// GetDepositAddress fetches the deposit addresses of a particular currency.
+//
+// A currency may have a deposit address on each network it supports.
+//
+// Method: private/get-deposit-address
+func (c *client) GetDepositAddress(ctx context.Context, currency string) ([]DepositAddress, error) {
+	if currency == "" {
+		return nil, errors.InvalidParameterError{Parameter: "currency", Reason: "cannot be empty"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = currency
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetDepositAddress,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetDepositAddress,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var depositAddressResponse GetDepositAddressResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetDepositAddress, &depositAddressResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, depositAddressResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return depositAddressResponse.Result.DepositAddressList, nil
+}
@@ -0,0 +1,240 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_GetDepositAddress_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "some currency"
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		currency     string
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			currency:     currency,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name:     "returns error given error making request",
+			currency: currency,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name:     "returns error given error response",
+			currency: currency,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			if tt.currency != "" {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetDepositAddress,
+					Timestamp: now.UnixMilli(),
+					Params:    map[string]interface{}{"currency": currency},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			addresses, err := client.GetDepositAddress(ctx, tt.currency)
+			require.Error(t, err)
+
+			assert.Empty(t, addresses)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetDepositAddress_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "CRO"
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetDepositAddress)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetDepositAddress, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, map[string]interface{}{"currency": currency}, body.Params)
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{
+			"id": 0,
+			"method": "",
+			"code": 0,
+			"result": {
+				"deposit_address_list": [
+					{
+						"currency": "CRO",
+						"create_time": %[1]d,
+						"id": "12345",
+						"address": "some address",
+						"status": "1",
+						"network": "CRO"
+					},
+					{
+						"currency": "CRO",
+						"create_time": %[1]d,
+						"id": "12346",
+						"address": "some other address",
+						"status": "0",
+						"network": "ETH"
+					}
+				]
+			}
+		}`, now.UnixMilli())))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetDepositAddress,
+		Timestamp: now.UnixMilli(),
+		Params:    map[string]interface{}{"currency": currency},
+	}).Return(signature, nil)
+
+	addresses, err := client.GetDepositAddress(ctx, currency)
+	require.NoError(t, err)
+
+	assert.Equal(t, []cdcexchange.DepositAddress{
+		{
+			ID:         "12345",
+			Currency:   "CRO",
+			Network:    "CRO",
+			Address:    "some address",
+			CreateTime: cdctime.Time(now),
+			Status:     cdcexchange.DepositAddressStatusActive,
+		},
+		{
+			ID:         "12346",
+			Currency:   "CRO",
+			Network:    "ETH",
+			Address:    "some other address",
+			CreateTime: cdctime.Time(now),
+			Status:     cdcexchange.DepositAddressStatusInactive,
+		},
+	}, addresses)
+}
@@ -0,0 +1,170 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetDepositHistory = "private/get-deposit-history"
+
+	DepositStatusNotArrived DepositStatus = "0"
+	DepositStatusArrived    DepositStatus = "1"
+	DepositStatusFailed     DepositStatus = "2"
+	DepositStatusPending    DepositStatus = "3"
+)
+
+type (
+	// DepositStatus is the status of a deposit.
+	DepositStatus string
+
+	// GetDepositHistoryRequest is the request params sent for the private/get-deposit-history API.
+	//
+	// The maximum duration between Start and End is 90 days.
+	GetDepositHistoryRequest struct {
+		// Currency is the currency of the deposits (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 90 days ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of deposits returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+		// Status is the status of the deposits.
+		// if Status is omitted, deposits of all statuses will be returned.
+		Status DepositStatus `json:"status"`
+	}
+
+	// GetDepositHistoryResponse is the base response returned from the private/get-deposit-history API.
+	GetDepositHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetDepositHistoryResult `json:"result"`
+	}
+
+	// GetDepositHistoryResult is the result returned from the private/get-deposit-history API.
+	GetDepositHistoryResult struct {
+		// DepositList is the array of deposits.
+		DepositList []Deposit `json:"deposit_list"`
+		// HasMore reports whether there may be more deposits after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further deposits exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// Deposit represents the details of a deposit.
+	Deposit struct {
+		// ID is the unique identifier of the deposit.
+		ID string `json:"id"`
+		// Currency is the currency of the deposit (e.g. BTC).
+		Currency string `json:"currency"`
+		// Amount is the amount of the deposit.
+		Amount decimal.Decimal `json:"amount"`
+		// Fee is the fee charged for the deposit.
+		Fee decimal.Decimal `json:"fee"`
+		// Address is the address of the deposit.
+		Address string `json:"address"`
+		// Status is the status of the deposit.
+		Status DepositStatus `json:"status"`
+		// CreateTime is the time the deposit was created.
+		CreateTime cdctime.Time `json:"create_time"`
+		// UpdateTime is the time the deposit was last updated.
+		UpdateTime cdctime.Time `json:"update_time"`
+	}
+)
+
+// GetDepositHistory gets the deposit history of the account.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/get-deposit-history
+func (c *client) GetDepositHistory(ctx context.Context, req GetDepositHistoryRequest) (*GetDepositHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	if req.Status != "" {
+		params["status"] = req.Status
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetDepositHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetDepositHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var depositHistoryResponse GetDepositHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetDepositHistory, &depositHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, depositHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := depositHistoryResponse.Result
+	if len(res.DepositList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
@@ -50,7 +50,7 @@ func (c *client) GetInstruments(ctx context.Context) ([]Instrument, error) {
 	body := api.Request{
 		ID:     c.idGenerator.Generate(),
 		Method: methodGetInstruments,
-		Nonce:  c.clock.Now().UnixMilli(),
+		Nonce:  c.nonce(),
 	}
 
 	var instrumentsResponse InstrumentsResponse
@@ -8,6 +8,8 @@ import (
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -49,9 +51,13 @@ type (
 	// GetOpenOrdersResult is the result returned from the private/get-open-orders API.
 	GetOpenOrdersResult struct {
 		// Count is the total count of orders.
-		Count int `json:"order_id"`
+		Count int `json:"count"`
 		// OrderList is the array of open orders.
 		OrderList []Order `json:"order_list"`
+		// HasMore reports whether there are more open orders after this page, based on Count.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
 	}
 
 	// Order represents the details of a specific order.
@@ -64,9 +70,9 @@ type (
 		// Side represents whether the order is buy or sell.
 		Side OrderSide `json:"side"`
 		// Price is the price specified in the order.
-		Price float64 `json:"price"`
+		Price decimal.Decimal `json:"price"`
 		// Quantity	is the quantity specified in the order.
-		Quantity float64 `json:"quantity"`
+		Quantity decimal.Decimal `json:"quantity"`
 		// OrderID is the unique identifier for the order.
 		OrderID string `json:"order_id"`
 		// ClientOID is the optional Client order ID (if provided in request when creating the order).
@@ -80,11 +86,11 @@ type (
 		// InstrumentName represents the currency pair to trade (e.g. ETH_CRO or BTC_USDT).
 		InstrumentName string `json:"instrument_name"`
 		// CumulativeQuantity is the cumulative-executed quantity (for partially filled orders).
-		CumulativeQuantity float64 `json:"cumulative_quantity"`
+		CumulativeQuantity decimal.Decimal `json:"cumulative_quantity"`
 		// CumulativeValue is the cumulative-executed value (for partially filled orders).
-		CumulativeValue float64 `json:"cumulative_value"`
+		CumulativeValue decimal.Decimal `json:"cumulative_value"`
 		// AvgPrice is the average filled price. If none is filled, 0 is returned.
-		AvgPrice float64 `json:"avg_price"`
+		AvgPrice decimal.Decimal `json:"avg_price"`
 		// FeeCurrency is the currency used for the fees (e.g. CRO).
 		FeeCurrency string `json:"fee_currency"`
 		// TimeInForce represents how long the order should be active before being cancelled.
@@ -99,18 +105,24 @@ type (
 		ExecInst ExecInst `json:"exec_inst"`
 		// TriggerPrice is the price at which the order is triggered.
 		// Used with STOP_LOSS, STOP_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
-		TriggerPrice float64 `json:"trigger_price"`
+		TriggerPrice decimal.Decimal `json:"trigger_price"`
 	}
 )
 
 // GetOpenOrders gets all open orders for a particular instrument.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
 //
 // req.InstrumentName can be left blank to get open orders for all instruments.
 //
 // Method: private/get-open-orders
 func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*GetOpenOrdersResult, error) {
+	return c.getOpenOrders(ctx, methodGetOpenOrders, req)
+}
+
+// getOpenOrders fetches a page of open orders from the specified method (spot or margin).
+func (c *client) getOpenOrders(ctx context.Context, method string, req GetOpenOrdersRequest) (*GetOpenOrdersResult, error) {
 	if req.PageSize < 0 {
 		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
 	}
@@ -120,7 +132,7 @@ func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -136,7 +148,7 @@ func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodGetOpenOrders,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -146,7 +158,7 @@ func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodGetOpenOrders,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -154,7 +166,7 @@ func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 	}
 
 	var getOpenOrdersResponse GetOpenOrdersResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOpenOrders, &getOpenOrdersResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &getOpenOrdersResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -163,5 +175,10 @@ func (c *client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 		return nil, fmt.Errorf("error received in response: %w", err)
 	}
 
-	return &getOpenOrdersResponse.Result, nil
+	res := getOpenOrdersResponse.Result
+	if (req.Page+1)*pageSize(req.PageSize) < res.Count {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
 }
@@ -205,7 +205,7 @@ func TestClient_GetOpenOrders_Success(t *testing.T) {
 							"method":"",
 							"code":0,
 							"result":{
-								"order_id":1234,"order_list":[
+								"count":1234,"order_list":[
 									{
 										"status":"",
 										"reason":"",
@@ -238,6 +238,8 @@ func TestClient_GetOpenOrders_Success(t *testing.T) {
 						UpdateTime: cdctime.Time(now),
 					},
 				},
+				HasMore:  true,
+				NextPage: 2,
 			},
 		},
 		{
@@ -264,7 +266,7 @@ func TestClient_GetOpenOrders_Success(t *testing.T) {
 							"method":"",
 							"code":0,
 							"result":{
-								"order_id":1234,"order_list":[
+								"count":1,"order_list":[
 									{
 										"status":"",
 										"reason":"",
@@ -287,7 +289,7 @@ func TestClient_GetOpenOrders_Success(t *testing.T) {
 				"page": 0,
 			},
 			expectedResult: cdcexchange.GetOpenOrdersResult{
-				Count: 1234,
+				Count: 1,
 				OrderList: []cdcexchange.Order{
 					{
 						ClientOID:  clientOID,
@@ -8,6 +8,8 @@ import (
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -44,15 +46,15 @@ type (
 		// InstrumentName represents the currency pair to trade (e.g. ETH_CRO or BTC_USDT).
 		InstrumentName string `json:"instrument_name"`
 		// Fee is the trade fee.
-		Fee float64 `json:"fee"`
+		Fee decimal.Decimal `json:"fee"`
 		// TradeID is the unique identifier for the trade.
 		TradeID string `json:"trade_id"`
 		// CreateTime is the trade creation time.
 		CreateTime time.Time `json:"create_time"`
 		// TradedPrice is the executed trade price
-		TradedPrice float64 `json:"traded_price"`
+		TradedPrice decimal.Decimal `json:"traded_price"`
 		// TradedQuantity is the executed trade quantity
-		TradedQuantity float64 `json:"traded_quantity"`
+		TradedQuantity decimal.Decimal `json:"traded_quantity"`
 		// FeeCurrency is the currency used for the fees (e.g. CRO).
 		FeeCurrency string `json:"fee_currency"`
 		// OrderID is the unique identifier for the order.
@@ -68,13 +70,18 @@ type (
 //
 // Method: private/get-order-detail
 func (c *client) GetOrderDetail(ctx context.Context, orderID string) (*GetOrderDetailResult, error) {
+	return c.getOrderDetail(ctx, methodGetOrderDetail, orderID)
+}
+
+// getOrderDetail fetches the details of an order from the specified method (spot or margin).
+func (c *client) getOrderDetail(ctx context.Context, method string, orderID string) (*GetOrderDetailResult, error) {
 	if orderID == "" {
 		return nil, errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
 	}
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -84,7 +91,7 @@ func (c *client) GetOrderDetail(ctx context.Context, orderID string) (*GetOrderD
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodGetOrderDetail,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -94,7 +101,7 @@ func (c *client) GetOrderDetail(ctx context.Context, orderID string) (*GetOrderD
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodGetOrderDetail,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -102,7 +109,7 @@ func (c *client) GetOrderDetail(ctx context.Context, orderID string) (*GetOrderD
 	}
 
 	var getOrderDetailResponse GetOrderDetailResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOrderDetail, &getOrderDetailResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &getOrderDetailResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
@@ -238,11 +239,11 @@ func TestClient_GetOrderDetail_Success(t *testing.T) {
 					{
 						Side:           cdcexchange.OrderSideBuy,
 						InstrumentName: "ETH_CRO",
-						Fee:            0.007,
+						Fee:            decimal.MustParse("0.007"),
 						TradeID:        "371303044218155296",
 						CreateTime:     cdctime.Time(now),
-						TradedPrice:    7,
-						TradedQuantity: 7,
+						TradedPrice:    decimal.MustParse("7"),
+						TradedQuantity: decimal.MustParse("7"),
 						FeeCurrency:    "CRO",
 						OrderID:        orderID,
 					},
@@ -256,9 +257,9 @@ func TestClient_GetOrderDetail_Success(t *testing.T) {
 					UpdateTime:         cdctime.Time(now),
 					OrderType:          cdcexchange.OrderTypeLimit,
 					InstrumentName:     "ETH_CRO",
-					CumulativeQuantity: 7,
-					CumulativeValue:    7,
-					AvgPrice:           7,
+					CumulativeQuantity: decimal.MustParse("7"),
+					CumulativeValue:    decimal.MustParse("7"),
+					AvgPrice:           decimal.MustParse("7"),
 					FeeCurrency:        "CRO",
 					TimeInForce:        cdcexchange.TimeInForceGoodTilCancelled,
 					ExecInst:           cdcexchange.ExecInstPostOnly,
@@ -21,8 +21,8 @@ type (
 	//
 	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
 	//
-	// For users looking to pull longer historical order data, users can create a loop to make a request
-	// for each 24-period from the desired start to end time.
+	// For users looking to pull longer historical order data, IterateOrderHistory splits the range
+	// into 24 hour windows & iterates over every page of each window.
 	GetOrderHistoryRequest struct {
 		// InstrumentName represents the currency pair for the orders (e.g. ETH_CRO or BTC_USDT).
 		// if InstrumentName is omitted, all instruments will be returned.
@@ -54,18 +54,30 @@ type (
 	GetOrderHistoryResult struct {
 		// OrderList is the array of orders.
 		OrderList []Order `json:"order_list"`
+		// HasMore reports whether there may be more orders after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further orders exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
 	}
 )
 
 // GetOrderHistory gets the order history for a particular instrument.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-// If paging is used, enumerate each page (starting with 0) until an empty order_list array appears in the response.
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
 //
 // req.InstrumentName can be left blank to get orders for all instruments.
 //
 // Method: private/get-order-history
-func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest) ([]Order, error) {
+func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest) (*GetOrderHistoryResult, error) {
+	return c.getOrderHistory(ctx, methodGetOrderHistory, req)
+}
+
+// getOrderHistory fetches a page of order history from the specified method (spot or margin).
+func (c *client) getOrderHistory(ctx context.Context, method string, req GetOrderHistoryRequest) (*GetOrderHistoryResult, error) {
 	if req.PageSize < 0 {
 		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
 	}
@@ -75,7 +87,7 @@ func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -97,7 +109,7 @@ func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodGetOrderHistory,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -107,7 +119,7 @@ func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodGetOrderHistory,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -115,7 +127,7 @@ func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 	}
 
 	var getOrderHistoryResponse GetOrderHistoryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOrderHistory, &getOrderHistoryResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &getOrderHistoryResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -124,5 +136,10 @@ func (c *client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 		return nil, fmt.Errorf("error received in response: %w", err)
 	}
 
-	return getOrderHistoryResponse.Result.OrderList, nil
+	res := getOrderHistoryResponse.Result
+	if len(res.OrderList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
 }
@@ -173,7 +173,7 @@ func TestClient_GetOrderHistory_Success(t *testing.T) {
 		handlerFunc func(w http.ResponseWriter, r *http.Request)
 		args
 		expectedParams map[string]interface{}
-		expectedResult []cdcexchange.Order
+		expectedResult *cdcexchange.GetOrderHistoryResult
 	}{
 		{
 			name: "successfully gets all orders for an instrument",
@@ -229,11 +229,13 @@ func TestClient_GetOrderHistory_Success(t *testing.T) {
 				"page_size":       100,
 				"page":            1,
 			},
-			expectedResult: []cdcexchange.Order{
-				{
-					ClientOID:  clientOID,
-					CreateTime: cdctime.Time(now),
-					UpdateTime: cdctime.Time(now),
+			expectedResult: &cdcexchange.GetOrderHistoryResult{
+				OrderList: []cdcexchange.Order{
+					{
+						ClientOID:  clientOID,
+						CreateTime: cdctime.Time(now),
+						UpdateTime: cdctime.Time(now),
+					},
 				},
 			},
 		},
@@ -283,11 +285,13 @@ func TestClient_GetOrderHistory_Success(t *testing.T) {
 			expectedParams: map[string]interface{}{
 				"page": 0,
 			},
-			expectedResult: []cdcexchange.Order{
-				{
-					ClientOID:  clientOID,
-					CreateTime: cdctime.Time(now),
-					UpdateTime: cdctime.Time(now),
+			expectedResult: &cdcexchange.GetOrderHistoryResult{
+				OrderList: []cdcexchange.Order{
+					{
+						ClientOID:  clientOID,
+						CreateTime: cdctime.Time(now),
+						UpdateTime: cdctime.Time(now),
+					},
 				},
 			},
 		},
@@ -348,12 +352,16 @@ func TestClient_GetOrderHistory_Success(t *testing.T) {
 				"page_size": 1,
 				"page":      2,
 			},
-			expectedResult: []cdcexchange.Order{
-				{
-					ClientOID:  clientOID,
-					CreateTime: cdctime.Time(now),
-					UpdateTime: cdctime.Time(now),
+			expectedResult: &cdcexchange.GetOrderHistoryResult{
+				OrderList: []cdcexchange.Order{
+					{
+						ClientOID:  clientOID,
+						CreateTime: cdctime.Time(now),
+						UpdateTime: cdctime.Time(now),
+					},
 				},
+				HasMore:  true,
+				NextPage: 3,
 			},
 		},
 	}
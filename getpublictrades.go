@@ -0,0 +1,75 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+const (
+	methodGetPublicTrades = "public/get-trades"
+)
+
+type (
+	// PublicTradesResponse is the base response returned from the public/get-trades API.
+	PublicTradesResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result PublicTradesResult `json:"result"`
+	}
+
+	// PublicTradesResult is the result returned from the public/get-trades API.
+	PublicTradesResult struct {
+		// Data is the returned trades, most recent first.
+		Data []MarketTrade `json:"data"`
+	}
+)
+
+// GetPublicTrades fetches the public trades executed on the Exchange for a particular instrument (e.g. BTC_USDT).
+//
+// Unlike GetTrades, this returns trades executed by any user and does not require authentication.
+//
+// instrument can be left blank to retrieve trades for ALL instruments.
+//
+// Method: public/get-trades
+func (c *client) GetPublicTrades(ctx context.Context, instrument string) ([]MarketTrade, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.requester.BaseURL, methodGetPublicTrades), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// if instrument is omitted, trades for ALL instruments are returned.
+	if instrument != "" {
+		q := req.URL.Query()
+		q.Add("instrument_name", instrument)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	res, err := c.requester.Do(req, methodGetPublicTrades)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var publicTradesResponse PublicTradesResponse
+	if err := json.Unmarshal(resBytes, &publicTradesResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(res.StatusCode, publicTradesResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return publicTradesResponse.Result.Data, nil
+}
@@ -0,0 +1,185 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_GetPublicTrades_Error(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		instrument = "some instrument"
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name        string
+		client      http.Client
+		expectedErr error
+	}{
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				now   = time.Now()
+				clock = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+			)
+			require.NoError(t, err)
+
+			trades, err := client.GetPublicTrades(ctx, instrument)
+			require.Error(t, err)
+
+			assert.Empty(t, trades)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetPublicTrades_Success(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name       string
+		instrument string
+	}{
+		{
+			name:       "returns trades for specific instrument",
+			instrument: instrument,
+		},
+		{
+			name:       "returns trades for all instruments",
+			instrument: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				clock = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetPublicTrades)
+				assert.Equal(t, http.MethodGet, r.Method)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				assert.Equal(t, tt.instrument, r.URL.Query().Get("instrument_name"))
+
+				res := fmt.Sprintf(`{
+					"id": 0,
+					"method": "public/get-trades",
+					"code": 0,
+					"result": {
+						"data": [
+							{"dataTime": %[1]d, "d": 2030407068, "s": "BUY", "p": 9606.29, "q": 0.0021, "t": %[1]d, "i": "BTC_USDT"},
+							{"dataTime": %[1]d, "d": 2030407067, "s": "SELL", "p": 9606.18, "q": 0.5, "t": %[1]d, "i": "BTC_USDT"}
+						]
+					}
+				}`, now.UnixMilli())
+
+				_, err := w.Write([]byte(res))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			)
+			require.NoError(t, err)
+
+			res, err := client.GetPublicTrades(ctx, tt.instrument)
+			require.NoError(t, err)
+
+			assert.Equal(t, []cdcexchange.MarketTrade{
+				{
+					TradeID:        2030407068,
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Price:          decimal.MustParse("9606.29"),
+					Quantity:       decimal.MustParse("0.0021"),
+					Timestamp:      cdctime.Time(now),
+				},
+				{
+					TradeID:        2030407067,
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideSell,
+					Price:          decimal.MustParse("9606.18"),
+					Quantity:       decimal.MustParse("0.5"),
+					Timestamp:      cdctime.Time(now),
+				},
+			}, res)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 )
 
 const (
@@ -52,21 +54,21 @@ type (
 		// Instrument is the instrument name (e.g. BTC_USDT, ETH_CRO, etc).
 		Instrument string `json:"i"`
 		// BidPrice is the current best bid price, 0 if there aren't any bids.
-		BidPrice float64 `json:"b"`
+		BidPrice decimal.Decimal `json:"b"`
 		// AskPrice is the current best ask price, 0 if there aren't any asks.
-		AskPrice float64 `json:"k"`
+		AskPrice decimal.Decimal `json:"k"`
 		// LatestTradePrice is the price of the latest trade, 0 if there weren't any trades.
-		LatestTradePrice float64 `json:"a"`
+		LatestTradePrice decimal.Decimal `json:"a"`
 		// Timestamp is the timestamp of the data.
 		Timestamp time.Time `json:"t"`
 		// Volume24H is the total 24h traded volume.
-		Volume24H float64 `json:"v"`
+		Volume24H decimal.Decimal `json:"v"`
 		// PriceHigh24h is the price of the 24h highest trade, 0 if there weren't any trades.
-		PriceHigh24h float64 `json:"h"`
+		PriceHigh24h decimal.Decimal `json:"h"`
 		// PriceLow24h is the price of the 24h lowest trade, 0 if there weren't any trades.
-		PriceLow24h float64 `json:"l"`
+		PriceLow24h decimal.Decimal `json:"l"`
 		// PriceChange24h is the 24-hour price change, 0 if there weren't any trades.
-		PriceChange24h float64 `json:"c"`
+		PriceChange24h decimal.Decimal `json:"c"`
 	}
 )
 
@@ -89,7 +91,7 @@ func (c *client) GetTickers(ctx context.Context, instrument string) ([]Ticker, e
 		req.URL.RawQuery = q.Encode()
 	}
 
-	res, err := c.requester.Client.Do(req)
+	res, err := c.requester.Do(req, methodGetTicker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -21,8 +21,8 @@ type (
 	//
 	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
 	//
-	// For users looking to pull longer historical trade data, users can create a loop to make a request
-	// for each 24-period from the desired start to end time.
+	// For users looking to pull longer historical trade data, IterateTrades splits the range
+	// into 24 hour windows & iterates over every page of each window.
 	GetTradesRequest struct {
 		// InstrumentName represents the currency pair for the trades (e.g. ETH_CRO or BTC_USDT).
 		// if InstrumentName is omitted, all instruments will be returned.
@@ -54,18 +54,30 @@ type (
 	GetTradesResult struct {
 		// TradeList is the array of trades.
 		TradeList []Trade `json:"trade_list"`
+		// HasMore reports whether there may be more trades after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further trades exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
 	}
 )
 
 // GetTrades gets all executed trades for a particular instrument.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-// If paging is used, enumerate each page (starting with 0) until an empty trade_list array appears in the response.
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
 //
 // req.InstrumentName can be left blank to get executed trades for all instruments.
 //
 // Method: private/get-trades
-func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade, error) {
+func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) (*GetTradesResult, error) {
+	return c.getTrades(ctx, methodGetTrades, req)
+}
+
+// getTrades fetches a page of executed trades from the specified method (spot or margin).
+func (c *client) getTrades(ctx context.Context, method string, req GetTradesRequest) (*GetTradesResult, error) {
 	if req.PageSize < 0 {
 		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
 	}
@@ -75,7 +87,7 @@ func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade,
 
 	var (
 		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
+		timestamp = c.nonce()
 		params    = make(map[string]interface{})
 	)
 
@@ -97,7 +109,7 @@ func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade,
 		APIKey:    c.apiKey,
 		SecretKey: c.secretKey,
 		ID:        id,
-		Method:    methodGetTrades,
+		Method:    method,
 		Timestamp: timestamp,
 		Params:    params,
 	})
@@ -107,7 +119,7 @@ func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade,
 
 	body := api.Request{
 		ID:        id,
-		Method:    methodGetTrades,
+		Method:    method,
 		Nonce:     timestamp,
 		Params:    params,
 		Signature: signature,
@@ -115,7 +127,7 @@ func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade,
 	}
 
 	var getTradesResponse GetTradesResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetTrades, &getTradesResponse)
+	statusCode, err := c.requester.Post(ctx, body, method, &getTradesResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute post request: %w", err)
 	}
@@ -124,5 +136,10 @@ func (c *client) GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade,
 		return nil, fmt.Errorf("error received in response: %w", err)
 	}
 
-	return getTradesResponse.Result.TradeList, nil
+	res := getTradesResponse.Result
+	if len(res.TradeList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
 }
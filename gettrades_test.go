@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
 	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
 	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
@@ -173,7 +174,7 @@ func TestClient_GetTrades_Success(t *testing.T) {
 		handlerFunc func(w http.ResponseWriter, r *http.Request)
 		args
 		expectedParams map[string]interface{}
-		expectedResult []cdcexchange.Trade
+		expectedResult *cdcexchange.GetTradesResult
 	}{
 		{
 			name: "successfully gets all trades for an instrument",
@@ -229,17 +230,19 @@ func TestClient_GetTrades_Success(t *testing.T) {
 				"page_size":       100,
 				"page":            1,
 			},
-			expectedResult: []cdcexchange.Trade{
-				{
-					Side:           cdcexchange.OrderSideSell,
-					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
-					TradeID:        "367107655537806900",
-					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
-					FeeCurrency:    "CRO",
-					OrderID:        "367107623521528450",
+			expectedResult: &cdcexchange.GetTradesResult{
+				TradeList: []cdcexchange.Trade{
+					{
+						Side:           cdcexchange.OrderSideSell,
+						InstrumentName: "ETH_CRO",
+						Fee:            decimal.MustParse("0.014"),
+						TradeID:        "367107655537806900",
+						CreateTime:     cdctime.Time(now),
+						TradedPrice:    decimal.MustParse("7"),
+						TradedQuantity: decimal.MustParse("1"),
+						FeeCurrency:    "CRO",
+						OrderID:        "367107623521528450",
+					},
 				},
 			},
 		},
@@ -289,17 +292,19 @@ func TestClient_GetTrades_Success(t *testing.T) {
 			expectedParams: map[string]interface{}{
 				"page": 0,
 			},
-			expectedResult: []cdcexchange.Trade{
-				{
-					Side:           cdcexchange.OrderSideSell,
-					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
-					TradeID:        "367107655537806900",
-					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
-					FeeCurrency:    "CRO",
-					OrderID:        "367107623521528450",
+			expectedResult: &cdcexchange.GetTradesResult{
+				TradeList: []cdcexchange.Trade{
+					{
+						Side:           cdcexchange.OrderSideSell,
+						InstrumentName: "ETH_CRO",
+						Fee:            decimal.MustParse("0.014"),
+						TradeID:        "367107655537806900",
+						CreateTime:     cdctime.Time(now),
+						TradedPrice:    decimal.MustParse("7"),
+						TradedQuantity: decimal.MustParse("1"),
+						FeeCurrency:    "CRO",
+						OrderID:        "367107623521528450",
+					},
 				},
 			},
 		},
@@ -360,18 +365,22 @@ func TestClient_GetTrades_Success(t *testing.T) {
 				"page_size": 1,
 				"page":      2,
 			},
-			expectedResult: []cdcexchange.Trade{
-				{
-					Side:           cdcexchange.OrderSideSell,
-					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
-					TradeID:        "367107655537806900",
-					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
-					FeeCurrency:    "CRO",
-					OrderID:        "367107623521528450",
+			expectedResult: &cdcexchange.GetTradesResult{
+				TradeList: []cdcexchange.Trade{
+					{
+						Side:           cdcexchange.OrderSideSell,
+						InstrumentName: "ETH_CRO",
+						Fee:            decimal.MustParse("0.014"),
+						TradeID:        "367107655537806900",
+						CreateTime:     cdctime.Time(now),
+						TradedPrice:    decimal.MustParse("7"),
+						TradedQuantity: decimal.MustParse("1"),
+						FeeCurrency:    "CRO",
+						OrderID:        "367107623521528450",
+					},
 				},
+				HasMore:  true,
+				NextPage: 3,
 			},
 		},
 	}
@@ -0,0 +1,179 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetWithdrawalHistory = "private/get-withdrawal-history"
+
+	WithdrawalStatusPending           WithdrawalStatus = "0"
+	WithdrawalStatusProcessing        WithdrawalStatus = "1"
+	WithdrawalStatusRejected          WithdrawalStatus = "2"
+	WithdrawalStatusPaymentInProgress WithdrawalStatus = "3"
+	WithdrawalStatusPaymentFailed     WithdrawalStatus = "4"
+	WithdrawalStatusCompleted         WithdrawalStatus = "5"
+	WithdrawalStatusCancelled         WithdrawalStatus = "6"
+)
+
+type (
+	// WithdrawalStatus is the status of a withdrawal.
+	WithdrawalStatus string
+
+	// GetWithdrawalHistoryRequest is the request params sent for the private/get-withdrawal-history API.
+	//
+	// The maximum duration between Start and End is 90 days.
+	GetWithdrawalHistoryRequest struct {
+		// Currency is the currency of the withdrawals (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 90 days ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of withdrawals returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+		// Status is the status of the withdrawals.
+		// if Status is omitted, withdrawals of all statuses will be returned.
+		Status WithdrawalStatus `json:"status"`
+	}
+
+	// GetWithdrawalHistoryResponse is the base response returned from the private/get-withdrawal-history API.
+	GetWithdrawalHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetWithdrawalHistoryResult `json:"result"`
+	}
+
+	// GetWithdrawalHistoryResult is the result returned from the private/get-withdrawal-history API.
+	GetWithdrawalHistoryResult struct {
+		// WithdrawalList is the array of withdrawals.
+		WithdrawalList []Withdrawal `json:"withdrawal_list"`
+		// HasMore reports whether there may be more withdrawals after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further withdrawals exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// Withdrawal represents the details of a withdrawal.
+	Withdrawal struct {
+		// ID is the unique identifier of the withdrawal.
+		ID string `json:"id"`
+		// ClientWID is the client withdrawal ID (if provided when the withdrawal was created).
+		ClientWID string `json:"client_wid"`
+		// TxID is the transaction hash of the withdrawal on the network.
+		TxID string `json:"txid"`
+		// NetworkID is the network the withdrawal was made on (e.g. ETH, BSC).
+		NetworkID string `json:"network_id"`
+		// Currency is the currency of the withdrawal (e.g. BTC).
+		Currency string `json:"currency"`
+		// Amount is the amount of the withdrawal.
+		Amount decimal.Decimal `json:"amount"`
+		// Fee is the fee charged for the withdrawal.
+		Fee decimal.Decimal `json:"fee"`
+		// Address is the address of the withdrawal.
+		Address string `json:"address"`
+		// Status is the status of the withdrawal.
+		Status WithdrawalStatus `json:"status"`
+		// CreateTime is the time the withdrawal was created.
+		CreateTime cdctime.Time `json:"create_time"`
+		// UpdateTime is the time the withdrawal was last updated.
+		UpdateTime cdctime.Time `json:"update_time"`
+	}
+)
+
+// GetWithdrawalHistory gets the withdrawal history of the account.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/get-withdrawal-history
+func (c *client) GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHistoryRequest) (*GetWithdrawalHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	if req.Status != "" {
+		params["status"] = req.Status
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetWithdrawalHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetWithdrawalHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var withdrawalHistoryResponse GetWithdrawalHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetWithdrawalHistory, &withdrawalHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, withdrawalHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := withdrawalHistoryResponse.Result
+	if len(res.WithdrawalList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
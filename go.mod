@@ -1,10 +1,11 @@
 module github.com/cshep4/crypto-dot-com-exchange-go
 
-go 1.17
+go 1.21
 
 require (
 	github.com/golang/mock v1.6.0
-	github.com/jonboulle/clockwork v0.2.2
+	github.com/gorilla/websocket v1.5.0
+	github.com/jonboulle/clockwork v0.5.0
 	github.com/stretchr/testify v1.5.1
 )
 
@@ -0,0 +1,217 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/flight"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	// PrecisionModeNone sends the price & quantity of orders as specified (Default).
+	// Orders with too many decimal places are rejected by the Exchange with errors.ErrInvalidPricePrecision
+	// or errors.ErrInvalidQuantityPrecision.
+	PrecisionModeNone PrecisionMode = "NONE"
+	// PrecisionModeValidate rejects orders with too many decimal places before they are sent.
+	PrecisionModeValidate PrecisionMode = "VALIDATE"
+	// PrecisionModeRound rounds the price & quantity of orders to the precision of the instrument,
+	// with halves rounded away from zero.
+	PrecisionModeRound PrecisionMode = "ROUND"
+	// PrecisionModeTruncate truncates the price & quantity of orders towards zero to the precision of the instrument.
+	PrecisionModeTruncate PrecisionMode = "TRUNCATE"
+
+	defaultInstrumentRefreshInterval = time.Hour
+
+	// instrumentMissRefreshInterval is the minimum time between refreshes triggered by looking up an unknown instrument,
+	// so that repeatedly looking up an instrument which does not exist does not flood the Exchange with requests.
+	instrumentMissRefreshInterval = time.Minute
+
+	// instrumentLoadTimeout bounds a load of the instruments, which is not cancelled with the lookup which started it.
+	instrumentLoadTimeout = 30 * time.Second
+)
+
+type (
+	// PrecisionMode determines how the price & quantity of an order are fitted to the precision of its instrument.
+	PrecisionMode string
+
+	// instrumentRegistry is a cache of the instruments returned by public/get-instruments, keyed by instrument name.
+	instrumentRegistry struct {
+		mu          sync.Mutex
+		instruments map[string]Instrument
+		loadedAt    time.Time
+		// loads shares the load of the instruments between the lookups which need it.
+		loads flight.Group
+	}
+)
+
+// WithPrecisionMode sets how the price & quantity of orders are fitted to the precision of their instrument
+// (Default: PrecisionModeNone).
+//
+// The precision of each instrument is loaded from public/get-instruments when it is first needed.
+func WithPrecisionMode(mode PrecisionMode) ClientOption {
+	return func(c *client) error {
+		switch mode {
+		case PrecisionModeNone, PrecisionModeValidate, PrecisionModeRound, PrecisionModeTruncate:
+		default:
+			return errors.InvalidParameterError{Parameter: "mode", Reason: "is not a valid precision mode"}
+		}
+
+		c.precisionMode = mode
+		return nil
+	}
+}
+
+// WithInstrumentRefreshInterval sets how long instruments are cached before they are reloaded (Default: 1 hour).
+func WithInstrumentRefreshInterval(interval time.Duration) ClientOption {
+	return func(c *client) error {
+		if interval <= 0 {
+			return errors.InvalidParameterError{Parameter: "interval", Reason: "must be greater than 0"}
+		}
+
+		c.instrumentRefreshInterval = interval
+		return nil
+	}
+}
+
+// GetInstrument provides information on a supported instrument (e.g. BTC_USDT).
+//
+// Instruments are cached, the cache is loaded when first needed and reloaded once the refresh interval has passed
+// or an unknown instrument is requested.
+//
+// Method: public/get-instruments
+func (c *client) GetInstrument(ctx context.Context, instrumentName string) (*Instrument, error) {
+	if instrumentName == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
+	}
+
+	instrument, ok, err := c.instrument(ctx, instrumentName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.InvalidParameterError{Parameter: "instrumentName", Reason: "is not a known instrument"}
+	}
+
+	return &instrument, nil
+}
+
+// RefreshInstruments reloads the cache of instruments used by GetInstrument.
+//
+// Method: public/get-instruments
+func (c *client) RefreshInstruments(ctx context.Context) error {
+	return c.loadInstruments(ctx)
+}
+
+// instrument looks up an instrument in the cache, reloading the cache if it is stale or the instrument is unknown.
+func (c *client) instrument(ctx context.Context, instrumentName string) (Instrument, bool, error) {
+	instrument, ok, sinceLoaded := c.instruments.lookup(c.clock, instrumentName)
+
+	if sinceLoaded >= c.instrumentRefreshInterval || (!ok && sinceLoaded >= instrumentMissRefreshInterval) {
+		if err := c.loadInstruments(ctx); err != nil {
+			return Instrument{}, false, err
+		}
+		instrument, ok, _ = c.instruments.lookup(c.clock, instrumentName)
+	}
+
+	return instrument, ok, nil
+}
+
+// lookup returns the cached instrument (if any), along with the time since the cache was loaded.
+func (r *instrumentRegistry) lookup(clock clockwork.Clock, instrumentName string) (Instrument, bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instrument, ok := r.instruments[instrumentName]
+	return instrument, ok, clock.Since(r.loadedAt)
+}
+
+// loadInstruments replaces the cache of instruments.
+//
+// The cache is not locked while the instruments are fetched, so that lookups of cached instruments are not blocked.
+// Loads are shared, a load which is already in flight is waited for rather than starting another.
+func (c *client) loadInstruments(ctx context.Context) error {
+	r := c.instruments
+
+	err := r.loads.Do(ctx, instrumentLoadTimeout, func(ctx context.Context) error {
+		instruments, err := c.GetInstruments(ctx)
+		if err != nil {
+			return err
+		}
+
+		cache := make(map[string]Instrument, len(instruments))
+		for _, instrument := range instruments {
+			cache[instrument.InstrumentName] = instrument
+		}
+
+		r.mu.Lock()
+		r.instruments = cache
+		r.loadedAt = c.clock.Now()
+		r.mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load instruments: %w", err)
+	}
+
+	return nil
+}
+
+// fitPrecision fits the price, trigger price & quantity of an order to the precision of its instrument,
+// according to the client's precision mode.
+func (c *client) fitPrecision(ctx context.Context, req CreateOrderRequest) (CreateOrderRequest, error) {
+	if c.precisionMode == PrecisionModeNone {
+		return req, nil
+	}
+
+	instrument, ok, err := c.instrument(ctx, req.InstrumentName)
+	if err != nil {
+		return CreateOrderRequest{}, err
+	}
+	if !ok {
+		return CreateOrderRequest{}, errors.InvalidParameterError{Parameter: "req.InstrumentName", Reason: "is not a known instrument"}
+	}
+
+	if req.Price, err = c.fitDecimals("req.Price", req.Price, instrument.PriceDecimals); err != nil {
+		return CreateOrderRequest{}, err
+	}
+	if req.TriggerPrice, err = c.fitDecimals("req.TriggerPrice", req.TriggerPrice, instrument.PriceDecimals); err != nil {
+		return CreateOrderRequest{}, err
+	}
+	if req.Quantity, err = c.fitDecimals("req.Quantity", req.Quantity, instrument.QuantityDecimals); err != nil {
+		return CreateOrderRequest{}, err
+	}
+
+	return req, nil
+}
+
+// fitDecimals fits a value to the specified number of decimal places, according to the client's precision mode.
+func (c *client) fitDecimals(parameter string, value decimal.Decimal, places int) (decimal.Decimal, error) {
+	if value.Scale() <= int32(places) {
+		return value, nil
+	}
+
+	var fitted decimal.Decimal
+	switch c.precisionMode {
+	case PrecisionModeRound:
+		fitted = value.Round(int32(places))
+	case PrecisionModeTruncate:
+		fitted = value.Truncate(int32(places))
+	default:
+		return decimal.Zero, errors.InvalidParameterError{Parameter: parameter, Reason: fmt.Sprintf("cannot have more than %d decimal places", places)}
+	}
+
+	// a value which becomes 0 would be omitted from the request, changing the meaning of the order.
+	if fitted.IsZero() {
+		return decimal.Zero, errors.InvalidParameterError{Parameter: parameter, Reason: fmt.Sprintf("is 0 at %d decimal places", places)}
+	}
+
+	return fitted, nil
+}
@@ -0,0 +1,412 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+const instrumentsResponse = `{
+	"id": 0,
+	"method": "public/get-instruments",
+	"code": 0,
+	"result": {
+		"instruments": [
+			{
+				"instrument_name": "CRO_USDT",
+				"quote_currency": "USDT",
+				"base_currency": "CRO",
+				"price_decimals": 4,
+				"quantity_decimals": 1,
+				"margin_trading_enabled": true
+			}
+		]
+	}
+}`
+
+func TestClient_GetInstrument_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	t.Run("returns error when instrument name is empty", func(t *testing.T) {
+		client, err := cdcexchange.New(apiKey, secretKey)
+		require.NoError(t, err)
+
+		instrument, err := client.GetInstrument(context.Background(), "")
+		require.Error(t, err)
+
+		assert.Nil(t, instrument)
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}, err)
+	})
+
+	t.Run("returns error given error loading instruments", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+			_, err := w.Write([]byte(`{"id":0,"method":"","code":10003}`))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+
+		client, err := cdcexchange.New(apiKey, secretKey,
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		instrument, err := client.GetInstrument(context.Background(), "CRO_USDT")
+		require.Error(t, err)
+
+		assert.Nil(t, instrument)
+		assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+	})
+}
+
+func TestClient_GetInstrument_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetInstruments)
+		atomic.AddInt32(&requests, 1)
+
+		_, err := w.Write([]byte(instrumentsResponse))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	clock := clockwork.NewFakeClock()
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithInstrumentRefreshInterval(10*time.Minute),
+	)
+	require.NoError(t, err)
+
+	expected := &cdcexchange.Instrument{
+		InstrumentName:       "CRO_USDT",
+		QuoteCurrency:        "USDT",
+		BaseCurrency:         "CRO",
+		PriceDecimals:        4,
+		QuantityDecimals:     1,
+		MarginTradingEnabled: true,
+	}
+
+	// instruments are loaded when first needed.
+	instrument, err := client.GetInstrument(context.Background(), "CRO_USDT")
+	require.NoError(t, err)
+	assert.Equal(t, expected, instrument)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// cached instruments are used until the refresh interval has passed.
+	clock.Advance(5 * time.Minute)
+	instrument, err = client.GetInstrument(context.Background(), "CRO_USDT")
+	require.NoError(t, err)
+	assert.Equal(t, expected, instrument)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// an unknown instrument reloads the cache, but at most once per minute.
+	for i := 0; i < 2; i++ {
+		instrument, err = client.GetInstrument(context.Background(), "BTC_USDT")
+		require.Error(t, err)
+		assert.Nil(t, instrument)
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrumentName", Reason: "is not a known instrument"}, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	clock.Advance(10 * time.Minute)
+	_, err = client.GetInstrument(context.Background(), "CRO_USDT")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	require.NoError(t, client.RefreshInstruments(context.Background()))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+func TestClient_CreateOrder_Precision(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	tests := []struct {
+		name             string
+		mode             cdcexchange.PrecisionMode
+		req              cdcexchange.CreateOrderRequest
+		expectedPrice    string
+		expectedQuantity string
+		expectedErr      error
+	}{
+		{
+			name: "sends price & quantity as specified when precision mode is none",
+			mode: cdcexchange.PrecisionModeNone,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.123456"),
+				Quantity:       decimal.MustParse("10.25"),
+			},
+			expectedPrice:    "0.123456",
+			expectedQuantity: "10.25",
+		},
+		{
+			name: "sends price & quantity within the precision of the instrument",
+			mode: cdcexchange.PrecisionModeValidate,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.1234"),
+				Quantity:       decimal.MustParse("10.2"),
+			},
+			expectedPrice:    "0.1234",
+			expectedQuantity: "10.2",
+		},
+		{
+			name: "returns error when price has too many decimal places",
+			mode: cdcexchange.PrecisionModeValidate,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.12345"),
+				Quantity:       decimal.MustParse("10"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Price", Reason: "cannot have more than 4 decimal places"},
+		},
+		{
+			name: "returns error when quantity has too many decimal places",
+			mode: cdcexchange.PrecisionModeValidate,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.1234"),
+				Quantity:       decimal.MustParse("10.25"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Quantity", Reason: "cannot have more than 1 decimal places"},
+		},
+		{
+			name: "returns error when trigger price has too many decimal places",
+			mode: cdcexchange.PrecisionModeValidate,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeStopLimit,
+				Price:          decimal.MustParse("0.1234"),
+				Quantity:       decimal.MustParse("10"),
+				TriggerPrice:   decimal.MustParse("0.12345"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.TriggerPrice", Reason: "cannot have more than 4 decimal places"},
+		},
+		{
+			name: "rounds price & quantity to the precision of the instrument",
+			mode: cdcexchange.PrecisionModeRound,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.12345"),
+				Quantity:       decimal.MustParse("10.25"),
+			},
+			expectedPrice:    "0.1235",
+			expectedQuantity: "10.3",
+		},
+		{
+			name: "truncates price & quantity to the precision of the instrument",
+			mode: cdcexchange.PrecisionModeTruncate,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.12349"),
+				Quantity:       decimal.MustParse("10.29"),
+			},
+			expectedPrice:    "0.1234",
+			expectedQuantity: "10.2",
+		},
+		{
+			name: "returns error when quantity is rounded to 0",
+			mode: cdcexchange.PrecisionModeRound,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "CRO_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("0.1234"),
+				Quantity:       decimal.MustParse("0.04"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Quantity", Reason: "is 0 at 1 decimal places"},
+		},
+		{
+			name: "returns error when instrument name is empty",
+			mode: cdcexchange.PrecisionModeRound,
+			req: cdcexchange.CreateOrderRequest{
+				Side:     cdcexchange.OrderSideBuy,
+				Type:     cdcexchange.OrderTypeLimit,
+				Price:    decimal.MustParse("0.1234"),
+				Quantity: decimal.MustParse("10"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.InstrumentName", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when instrument is unknown",
+			mode: cdcexchange.PrecisionModeRound,
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeLimit,
+				Price:          decimal.MustParse("30000"),
+				Quantity:       decimal.MustParse("1"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.InstrumentName", Reason: "is not a known instrument"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, cdcexchange.MethodGetInstruments) {
+					_, err := w.Write([]byte(instrumentsResponse))
+					require.NoError(t, err)
+					return
+				}
+
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateOrder)
+				require.Nil(t, tt.expectedErr, "order should not be sent")
+
+				var body api.Request
+				decoder := json.NewDecoder(r.Body)
+				decoder.UseNumber()
+				require.NoError(t, decoder.Decode(&body))
+
+				assert.Equal(t, json.Number(tt.expectedPrice), body.Params["price"])
+				assert.Equal(t, json.Number(tt.expectedQuantity), body.Params["quantity"])
+
+				_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{"order_id":"1"}}`))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithPrecisionMode(tt.mode),
+			)
+			require.NoError(t, err)
+
+			res, err := client.CreateOrder(context.Background(), tt.req)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.Nil(t, res)
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "1", res.OrderID)
+		})
+	}
+}
+
+func TestClient_GetInstrument_Loading(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	var (
+		requests int32
+		loading  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// block every load but the first, until released.
+		if atomic.AddInt32(&requests, 1) > 1 {
+			loading <- struct{}{}
+			<-release
+		}
+
+		_, err := w.Write([]byte(instrumentsResponse))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	clock := clockwork.NewFakeClock()
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRateLimitDisabled(),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetInstrument(context.Background(), "CRO_USDT")
+	require.NoError(t, err)
+
+	refreshed := make(chan error, 1)
+	go func() {
+		refreshed <- client.RefreshInstruments(context.Background())
+	}()
+
+	select {
+	case <-loading:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for instruments to be refreshed")
+	}
+
+	// cached instruments can be looked up while the cache is refreshed.
+	found := make(chan error, 1)
+	go func() {
+		_, err := client.GetInstrument(context.Background(), "CRO_USDT")
+		found <- err
+	}()
+
+	select {
+	case err := <-found:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("lookup of a cached instrument blocked by refresh")
+	}
+
+	// a lookup which needs the cache to be reloaded waits for the refresh in flight, until its context is done.
+	clock.Advance(2 * time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.GetInstrument(ctx, "BTC_USDT")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	close(release)
+
+	select {
+	case err := <-refreshed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for instruments to be refreshed")
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,50 @@
+package cdcexchange
+
+import (
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+type (
+	// Interceptor intercepts each HTTP request made by the client, so that logging, metrics, tracing, auditing,
+	// fault injection, etc. can be plugged in.
+	//
+	// An interceptor should call invoke to send the call on to the next interceptor in the chain, or the Exchange
+	// if it is the last. It can inspect or replace the call before invoking it, and the result after; the request
+	// sent is built from the call passed to invoke, although a replaced request is not signed again.
+	// Returning a result without calling invoke short-circuits the request, the result is handled by the client
+	// as if it had been returned by the Exchange.
+	//
+	// Interceptors are called once for each attempt of a request, so retries & nonce resyncs are each intercepted.
+	// Each call of invoke sends the request again, e.g. so that an interceptor can hedge or retry a request itself.
+	Interceptor = api.Interceptor
+
+	// Invoker sends a Call on to the next interceptor in the chain.
+	Invoker = api.Invoker
+
+	// Call describes an HTTP request made to a method of the Exchange (e.g. private/create-order),
+	// along with the signed request body.
+	Call = api.Call
+
+	// CallResult is the outcome of a Call: the HTTP status code & header, raw body, decoded response code & latency.
+	CallResult = api.CallResult
+)
+
+// WithInterceptors sets the interceptors called for each REST request made by the client (Default: none).
+// The first interceptor is the outermost, so interceptors are called in the order they are specified.
+//
+// Websocket messages are not intercepted.
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(c *client) error {
+		for i, interceptor := range interceptors {
+			if interceptor == nil {
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("interceptors[%d]", i), Reason: "cannot be empty"}
+			}
+		}
+
+		c.interceptors = interceptors
+		return nil
+	}
+}
@@ -0,0 +1,228 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+// slowTransport advances the clock while a request is in flight, to simulate latency.
+type slowTransport struct {
+	clock   *clockwork.FakeClock
+	latency time.Duration
+	base    http.RoundTripper
+}
+
+func (t slowTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.clock.Advance(t.latency)
+	return t.base.RoundTrip(r)
+}
+
+func TestClient_Interceptors(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		latency   = 250 * time.Millisecond
+	)
+
+	newClient := func(t *testing.T, code int, interceptors ...cdcexchange.Interceptor) (cdcexchange.CryptoDotComExchange, *int) {
+		var requests int
+
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			status := http.StatusOK
+			if code != 0 {
+				status = http.StatusBadRequest
+			}
+
+			w.WriteHeader(status)
+			_, err := w.Write([]byte(fmt.Sprintf(`{"id":1,"method":"","code":%d,"result":{"data":[]}}`, code)))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+
+		clock := clockwork.NewFakeClock()
+
+		client, err := cdcexchange.New(apiKey, secretKey,
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(&http.Client{
+				Transport: slowTransport{clock: clock, latency: latency, base: s.Client().Transport},
+			}),
+			cdcexchange.WithBaseURL(s.URL),
+			cdcexchange.WithRateLimitDisabled(),
+			cdcexchange.WithInterceptors(interceptors...),
+		)
+		require.NoError(t, err)
+
+		return client, &requests
+	}
+
+	t.Run("calls interceptors in order with the call & result", func(t *testing.T) {
+		var calls []string
+		record := func(name string) cdcexchange.Interceptor {
+			return func(ctx context.Context, call cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+				calls = append(calls, name+" before")
+				result, err := invoke(ctx, call)
+				calls = append(calls, name+" after")
+				return result, err
+			}
+		}
+
+		var (
+			call   cdcexchange.Call
+			result cdcexchange.CallResult
+		)
+		capture := func(ctx context.Context, c cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+			call = c
+			r, err := invoke(ctx, c)
+			result = r
+			return r, err
+		}
+
+		client, requests := newClient(t, 10003, record("first"), record("second"), capture)
+
+		_, err := client.GetOpenOrders(context.Background(), cdcexchange.GetOpenOrdersRequest{InstrumentName: "CRO_USDT"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+
+		assert.Equal(t, 1, *requests)
+		assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+
+		assert.Equal(t, cdcexchange.MethodGetOpenOrders, call.Method)
+		assert.Equal(t, cdcexchange.MethodGetOpenOrders, call.Request.Method)
+		assert.Equal(t, apiKey, call.Request.APIKey)
+		assert.NotEmpty(t, call.Request.Signature)
+		assert.Equal(t, "CRO_USDT", call.Request.Params["instrument_name"])
+
+		assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+		assert.Equal(t, "10003", result.Code.String())
+		assert.Equal(t, latency, result.Latency)
+		assert.Contains(t, string(result.Body), `"code":10003`)
+	})
+
+	t.Run("intercepts public requests with query params", func(t *testing.T) {
+		var call cdcexchange.Call
+		capture := func(ctx context.Context, c cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+			call = c
+			return invoke(ctx, c)
+		}
+
+		client, _ := newClient(t, 0, capture)
+
+		_, err := client.GetBook(context.Background(), "CRO_USDT", 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, cdcexchange.MethodGetBook, call.Method)
+		assert.Equal(t, "CRO_USDT", call.Request.Params["instrument_name"])
+		assert.Equal(t, "10", call.Request.Params["depth"])
+	})
+
+	t.Run("call passed to invoke is sent", func(t *testing.T) {
+		var (
+			queries []url.Values
+			bodies  []map[string]interface{}
+		)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.Query())
+
+			var body map[string]interface{}
+			if r.Method == http.MethodPost {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			}
+			bodies = append(bodies, body)
+
+			_, err := w.Write([]byte(`{"id":1,"method":"","code":0,"result":{"data":[]}}`))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+
+		replace := func(ctx context.Context, c cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+			params := make(map[string]interface{}, len(c.Request.Params))
+			for k, v := range c.Request.Params {
+				params[k] = v
+			}
+			params["instrument_name"] = "ETH_USDT"
+			c.Request.Params = params
+
+			// the request is built again for each invocation, so it can be sent more than once.
+			if _, err := invoke(ctx, c); err != nil {
+				return cdcexchange.CallResult{}, err
+			}
+			return invoke(ctx, c)
+		}
+
+		client, err := cdcexchange.New(apiKey, secretKey,
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(s.URL),
+			cdcexchange.WithRateLimitDisabled(),
+			cdcexchange.WithInterceptors(replace),
+		)
+		require.NoError(t, err)
+
+		_, err = client.GetBook(context.Background(), "CRO_USDT", 10)
+		require.NoError(t, err)
+
+		require.Len(t, queries, 2)
+		for _, q := range queries {
+			assert.Equal(t, "ETH_USDT", q.Get("instrument_name"))
+			assert.Equal(t, "10", q.Get("depth"))
+		}
+
+		queries, bodies = nil, nil
+
+		_, err = client.GetOpenOrders(context.Background(), cdcexchange.GetOpenOrdersRequest{InstrumentName: "CRO_USDT"})
+		require.NoError(t, err)
+
+		require.Len(t, bodies, 2)
+		for _, body := range bodies {
+			params, ok := body["params"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, "ETH_USDT", params["instrument_name"])
+			assert.Equal(t, apiKey, body["api_key"])
+		}
+	})
+
+	t.Run("result returned without invoking is handled as the response", func(t *testing.T) {
+		inject := func(ctx context.Context, c cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+			return cdcexchange.CallResult{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       []byte(`{"id":1,"method":"","code":10006}`),
+			}, nil
+		}
+
+		client, requests := newClient(t, 0, inject)
+
+		_, err := client.GetInstruments(context.Background())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, cdcerrors.ErrTooManyRequests))
+		assert.Zero(t, *requests)
+	})
+
+	t.Run("error returned by interceptor is returned", func(t *testing.T) {
+		interceptorErr := errors.New("some error")
+		fail := func(ctx context.Context, c cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+			return cdcexchange.CallResult{}, interceptorErr
+		}
+
+		client, requests := newClient(t, 0, fail)
+
+		_, err := client.GetInstruments(context.Background())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, interceptorErr))
+		assert.Zero(t, *requests)
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type (
+	// Call is a single HTTP request made to a method of the API.
+	Call struct {
+		// Method is the API method being called (e.g. private/create-order).
+		Method string
+		// Request is the outgoing request, as signed. Requests to public methods sent with query params
+		// (e.g. public/get-book) have the query params as Params, with no signature or nonce.
+		//
+		// The request passed to invoke is the request sent, however the method called is not changed
+		// if Method or Request.Method are, and a changed request is not signed again.
+		Request Request
+	}
+
+	// CallResult is the outcome of a Call.
+	CallResult struct {
+		// StatusCode is the HTTP status code of the response.
+		StatusCode int
+		// Header is the header of the response.
+		Header http.Header
+		// Body is the raw body of the response.
+		Body []byte
+		// Code is the response code decoded from the body, empty if the body has no code.
+		Code json.Number
+		// Latency is the time taken to receive the response.
+		Latency time.Duration
+	}
+
+	// Invoker sends a Call, returning its result.
+	Invoker func(ctx context.Context, call Call) (CallResult, error)
+
+	// Interceptor intercepts a Call, it may inspect or replace the call & its result, and should call invoke to send
+	// the call on to the next interceptor in the chain (or the API).
+	Interceptor func(ctx context.Context, call Call, invoke Invoker) (CallResult, error)
+)
+
+// roundTrip sends a single HTTP request through the interceptors of the requester.
+//
+// The HTTP request is built from the call passed to the innermost invoke, so interceptors can replace the call,
+// and it is built again each time invoke is called. The response is built from the result returned by the interceptors,
+// so that they can replace it.
+func (r Requester) roundTrip(ctx context.Context, body Request, build requestBuilder) (*http.Response, error) {
+	send := func(ctx context.Context, body Request) (*http.Response, error) {
+		req, err := build(ctx, body)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.Client.Do(req)
+	}
+
+	if len(r.Interceptors) == 0 {
+		return send(ctx, body)
+	}
+
+	var res *http.Response
+	invoke := func(ctx context.Context, call Call) (CallResult, error) {
+		start := r.Clock.Now()
+
+		var err error
+		res, err = send(ctx, call.Request)
+
+		result := CallResult{Latency: r.Clock.Since(start)}
+		if err != nil {
+			return result, err
+		}
+
+		b, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return result, err
+		}
+
+		var baseResponse BaseResponse
+		if err := json.Unmarshal(b, &baseResponse); err == nil {
+			result.Code = baseResponse.Code
+		}
+		result.StatusCode = res.StatusCode
+		result.Header = res.Header
+		result.Body = b
+
+		return result, nil
+	}
+
+	// the first interceptor is the outermost, so that interceptors are called in the order they were specified.
+	for i := len(r.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := r.Interceptors[i], invoke
+		invoke = func(ctx context.Context, call Call) (CallResult, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+
+	result, err := invoke(ctx, Call{Method: body.Method, Request: body})
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &http.Response{
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+		}
+	}
+	if result.Header != nil {
+		res.Header = result.Header
+	}
+	res.StatusCode = result.StatusCode
+	res.Status = fmt.Sprintf("%d %s", result.StatusCode, http.StatusText(result.StatusCode))
+	res.Body = ioutil.NopCloser(bytes.NewReader(result.Body))
+	res.ContentLength = int64(len(result.Body))
+
+	return res, nil
+}
@@ -4,17 +4,55 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jonboulle/clockwork"
 
 	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
 )
 
-type Requester struct {
-	Client  *http.Client
-	BaseURL string
-}
+// codeInvalidNonce is the response code returned if the nonce of a request is too far from the Exchange's clock.
+const codeInvalidNonce = "10007"
+
+type (
+	// Limiter limits the rate at which requests are made to each method.
+	Limiter interface {
+		// Wait blocks until a request can be made to the method, or ctx is done.
+		Wait(ctx context.Context, method string) error
+	}
+
+	Requester struct {
+		Client  *http.Client
+		BaseURL string
+		// Limiter is optional, requests are not rate limited if nil.
+		Limiter Limiter
+		// Retry is optional, failed requests are not retried if nil.
+		Retry *RetryPolicy
+		// ResyncNonce is optional, it is called once if a request is rejected with an invalid nonce
+		// and returns the request with an updated nonce (& signature) to be sent again.
+		ResyncNonce func(ctx context.Context, body Request) (Request, error)
+		// Interceptors are optional, they are called in order for each HTTP request sent.
+		Interceptors []Interceptor
+		// Clock is used to measure the latency of requests for Interceptors, it is required if there are any.
+		Clock clockwork.Clock
+	}
+
+	// RetryPolicy retries requests which fail with a transient error (network errors, 5xx status codes,
+	// system errors & exceeded rate limits), waiting with an exponential backoff between attempts.
+	RetryPolicy struct {
+		Clock      clockwork.Clock
+		MaxRetries int
+		MinBackoff time.Duration
+		MaxBackoff time.Duration
+	}
+)
 
 func (r Requester) Post(ctx context.Context, body Request, method string, response interface{}) (int, error) {
 	return r.doRequest(ctx, http.MethodPost, body, method, response)
@@ -25,33 +63,182 @@ func (r Requester) Get(ctx context.Context, body Request, method string, respons
 }
 
 func (r Requester) doRequest(ctx context.Context, httpMethod string, body Request, method string, response interface{}) (int, error) {
-	b, err := json.Marshal(body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to marshal request body: %w", err)
+	statusCode, code, err := r.send(ctx, httpMethod, body, method, response)
+	if err != nil || r.ResyncNonce == nil || code != codeInvalidNonce {
+		return statusCode, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, httpMethod, fmt.Sprintf("%s%s", r.BaseURL, method), bytes.NewBuffer(b))
+	body, err = r.ResyncNonce(ctx, body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to resync nonce: %w", err)
+	}
+
+	statusCode, _, err = r.send(ctx, httpMethod, body, method, response)
+
+	return statusCode, err
+}
+
+// send makes a single request, returning the status code & response code along with the unmarshalled response.
+func (r Requester) send(ctx context.Context, httpMethod string, body Request, method string, response interface{}) (int, json.Number, error) {
+	build := func(ctx context.Context, body Request) (*http.Request, error) {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, httpMethod, fmt.Sprintf("%s%s", r.BaseURL, method), bytes.NewBuffer(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		return req, nil
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	res, err := r.Client.Do(req)
+	res, err := r.do(ctx, body, build)
 	if err != nil {
-		return 0, fmt.Errorf("failed to do request: %w", err)
+		return 0, "", fmt.Errorf("failed to do request: %w", err)
 	}
 	defer res.Body.Close()
 
 	resBytes, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response body: %w", err)
+		return 0, "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if err := json.Unmarshal(resBytes, &response); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal response body: %w", err)
+		return 0, "", fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	// the response code is read separately, as the response may not embed BaseResponse.
+	var baseResponse BaseResponse
+	if err := json.Unmarshal(resBytes, &baseResponse); err != nil {
+		return res.StatusCode, "", nil
+	}
+
+	return res.StatusCode, baseResponse.Code, nil
+}
+
+// WithoutRetries returns a copy of the requester which does not retry failed requests.
+// This should be used for requests which are not safe to repeat (e.g. creating an order without a client order ID).
+func (r Requester) WithoutRetries() Requester {
+	r.Retry = nil
+	return r
+}
+
+// Do sends req once it is allowed by the rate limit of the method,
+// retrying transient failures according to the retry policy.
+//
+// Do is used for requests without a body, the query params of req are seen by interceptors as the params of the request.
+func (r Requester) Do(req *http.Request, method string) (*http.Response, error) {
+	body := Request{Method: method}
+	if q := req.URL.Query(); len(q) > 0 {
+		body.Params = make(map[string]interface{}, len(q))
+		for k := range q {
+			body.Params[k] = q.Get(k)
+		}
+	}
+
+	build := func(ctx context.Context, body Request) (*http.Request, error) {
+		next := req.Clone(ctx)
+
+		q := make(url.Values, len(body.Params))
+		for k, v := range body.Params {
+			q.Set(k, fmt.Sprint(v))
+		}
+		next.URL.RawQuery = q.Encode()
+
+		return next, nil
+	}
+
+	return r.do(req.Context(), body, build)
+}
+
+// requestBuilder builds the HTTP request of body, it is called for each attempt so that the request can be sent again.
+type requestBuilder func(ctx context.Context, body Request) (*http.Request, error)
+
+// do sends the HTTP request of body once it is allowed by the rate limit of the method,
+// retrying transient failures according to the retry policy.
+func (r Requester) do(ctx context.Context, body Request, build requestBuilder) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if r.Limiter != nil {
+			if err := r.Limiter.Wait(ctx, body.Method); err != nil {
+				return nil, fmt.Errorf("failed to wait for rate limit: %w", err)
+			}
+		}
+
+		res, err := r.roundTrip(ctx, body, build)
+		if r.Retry == nil || attempt >= r.Retry.MaxRetries {
+			return res, err
+		}
+
+		if !retryable(ctx, res, err) {
+			return res, err
+		}
+
+		select {
+		case <-r.Retry.Clock.After(r.Retry.backoff(attempt)):
+		case <-ctx.Done():
+			return res, err
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+	}
+}
+
+// retryable reports whether a request failed with a transient error.
+// The body of an error response is buffered, so that it can still be read if the request is not retried.
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	switch {
+	case err != nil:
+		// only transport errors are transient, errors building the request or returned by interceptors are not,
+		// nor are errors caused by the context being done.
+		var urlErr *url.Error
+		var netErr net.Error
+		return ctx.Err() == nil && (stderrors.As(err, &urlErr) || stderrors.As(err, &netErr))
+	case res.StatusCode >= http.StatusInternalServerError:
+		return true
+	case res.StatusCode < http.StatusBadRequest:
+		return false
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
 	}
 
-	return res.StatusCode, nil
+	var response BaseResponse
+	if err := json.Unmarshal(b, &response); err != nil {
+		return false
+	}
+
+	code, err := response.Code.Int64()
+	if err != nil {
+		return false
+	}
+
+	err = errors.NewResponseError(res.StatusCode, code)
+
+	return stderrors.Is(err, errors.ErrSystemError) || stderrors.Is(err, errors.ErrTooManyRequests)
+}
+
+// backoff returns the delay before the specified retry attempt, doubling with each attempt up to the maximum.
+// Half of the delay is random so that requests which fail together are not retried together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.MaxBackoff
+	if attempt < 32 {
+		if b := p.MinBackoff << attempt; b > 0 && b < backoff {
+			backoff = b
+		}
+	}
+
+	half := int64(backoff / 2)
+
+	return time.Duration(half + rand.Int63n(half+1))
 }
 
 func (Requester) CheckErrorResponse(statusCode int, responseCode json.Number) error {
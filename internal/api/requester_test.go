@@ -46,6 +46,14 @@ func (rt roundTripper) RoundTrip(*http.Request) (*http.Response, error) {
 	}, rt.err
 }
 
+type limiter struct {
+	err error
+}
+
+func (l limiter) Wait(context.Context, string) error {
+	return l.err
+}
+
 func TestRequester_Post_Error(t *testing.T) {
 	type args struct {
 		ctx    context.Context
@@ -53,8 +61,9 @@ func TestRequester_Post_Error(t *testing.T) {
 		method string
 	}
 	tests := []struct {
-		name   string
-		client http.Client
+		name    string
+		client  http.Client
+		limiter api.Limiter
 		args
 		expectedStatusCode int
 		expectedErr        error
@@ -68,6 +77,16 @@ func TestRequester_Post_Error(t *testing.T) {
 			},
 			expectedErr: errors.New("net/http: nil Context"),
 		},
+		{
+			name: "returns error if error waiting for rate limit",
+			args: args{
+				ctx:    context.Background(),
+				body:   api.Request{},
+				method: "some method",
+			},
+			limiter:     limiter{err: context.DeadlineExceeded},
+			expectedErr: context.DeadlineExceeded,
+		},
 		{
 			name: "returns error if error from server",
 			args: args{
@@ -104,7 +123,8 @@ func TestRequester_Post_Error(t *testing.T) {
 			t.Cleanup(ctrl.Finish)
 
 			requester := api.Requester{
-				Client: &tt.client,
+				Client:  &tt.client,
+				Limiter: tt.limiter,
 			}
 
 			var response api.BaseResponse
@@ -1,6 +1,12 @@
 package api
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// redacted replaces the value of secrets in logs.
+const redacted = "REDACTED"
 
 type (
 	Request struct {
@@ -18,3 +24,22 @@ type (
 		Code   json.Number `json:"code"`
 	}
 )
+
+// LogValue implements slog.LogValuer, so that the API key & signature of a request are never logged.
+// The secret key is never part of a request, only the signature generated with it.
+func (r Request) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int64("id", r.ID),
+		slog.String("method", r.Method),
+		slog.Int64("nonce", r.Nonce),
+		slog.Any("params", r.Params),
+	}
+	if r.APIKey != "" {
+		attrs = append(attrs, slog.String("api_key", redacted))
+	}
+	if r.Signature != "" {
+		attrs = append(attrs, slog.String("sig", redacted))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,81 @@
+package auth_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+)
+
+func TestGenerator_GenerateSignature(t *testing.T) {
+	req := auth.SignatureRequest{
+		APIKey:    "some api key",
+		SecretKey: "some secret key",
+		ID:        1234,
+		Method:    "private/create-order",
+		Timestamp: 1587846358253,
+	}
+
+	t.Run("signs decimals with the same param string as the equivalent floats", func(t *testing.T) {
+		floatReq := req
+		floatReq.Params = map[string]interface{}{
+			"instrument_name": "BTC_USDT",
+			"price":           9668.44,
+			"quantity":        0.006325,
+			"notional":        float64(100),
+		}
+
+		decimalReq := req
+		decimalReq.Params = map[string]interface{}{
+			"instrument_name": "BTC_USDT",
+			"price":           decimal.MustParse("9668.44"),
+			"quantity":        decimal.MustParse("0.006325"),
+			"notional":        decimal.MustParse("100.00"),
+		}
+
+		expected, err := auth.Generator{}.GenerateSignature(floatReq)
+		require.NoError(t, err)
+
+		signature, err := auth.Generator{}.GenerateSignature(decimalReq)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, signature)
+	})
+
+	t.Run("signs decimals of very small & large magnitudes as they are sent", func(t *testing.T) {
+		decimalReq := req
+		decimalReq.Params = map[string]interface{}{
+			"price":    decimal.MustParse("1e-8"),
+			"quantity": decimal.MustParse("1.5e21"),
+		}
+
+		// the Exchange signs the params as they appear in the request body, which has no exponents
+		// (unlike %v of the equivalent floats, 1e-08 & 1.5e+21).
+		b, err := json.Marshal(decimalReq.Params)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"price":0.00000001,"quantity":1500000000000000000000}`, string(b))
+
+		h := hmac.New(sha256.New, []byte(req.SecretKey))
+		_, err = h.Write([]byte("private/create-order1234some api keyprice0.00000001quantity15000000000000000000001587846358253"))
+		require.NoError(t, err)
+
+		signature, err := auth.Generator{}.GenerateSignature(decimalReq)
+		require.NoError(t, err)
+
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), signature)
+	})
+
+	t.Run("signs request without params", func(t *testing.T) {
+		signature, err := auth.Generator{}.GenerateSignature(req)
+		require.NoError(t, err)
+
+		assert.Len(t, signature, 64)
+	})
+}
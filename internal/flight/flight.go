@@ -0,0 +1,57 @@
+package flight
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// Group shares a call between the callers which make it while it is in flight,
+	// so that concurrent callers wait for a single call rather than each making their own.
+	Group struct {
+		mu   sync.Mutex
+		call *call
+	}
+
+	call struct {
+		// done is closed once the call completes, err is set before.
+		done chan struct{}
+		err  error
+	}
+)
+
+// Do makes the call to fn, or waits for the call already in flight.
+//
+// fn is called with a context detached from the cancellation of the caller which made the call, bounded by timeout,
+// so that a caller which gives up does not fail the others waiting for the call.
+// If ctx is done before the call completes, the context's error is returned and the call continues for the other callers.
+func (g *Group) Do(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	g.mu.Lock()
+	c := g.call
+	if c == nil {
+		c = &call{done: make(chan struct{})}
+		g.call = c
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+			defer cancel()
+
+			c.err = fn(ctx)
+
+			g.mu.Lock()
+			g.call = nil
+			g.mu.Unlock()
+
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
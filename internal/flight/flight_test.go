@@ -0,0 +1,124 @@
+package flight_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/flight"
+)
+
+func TestGroup_Do(t *testing.T) {
+	t.Run("returns the error of the call", func(t *testing.T) {
+		var g flight.Group
+
+		callErr := errors.New("some error")
+		err := g.Do(context.Background(), time.Second, func(ctx context.Context) error {
+			return callErr
+		})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, callErr))
+	})
+
+	t.Run("callers share the call in flight", func(t *testing.T) {
+		var (
+			g       flight.Group
+			calls   int
+			started = make(chan struct{})
+			release = make(chan struct{})
+		)
+		fn := func(ctx context.Context) error {
+			calls++
+			close(started)
+			<-release
+			return nil
+		}
+
+		first := make(chan error)
+		go func() {
+			first <- g.Do(context.Background(), time.Second, fn)
+		}()
+		<-started
+
+		second := make(chan error)
+		go func() {
+			second <- g.Do(context.Background(), time.Second, func(ctx context.Context) error {
+				t.Error("call made while another was in flight")
+				return nil
+			})
+		}()
+
+		select {
+		case <-second:
+			t.Fatal("caller returned before the call in flight completed")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		close(release)
+
+		require.NoError(t, <-first)
+		require.NoError(t, <-second)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("call is not cancelled with the caller which made it", func(t *testing.T) {
+		var (
+			g       flight.Group
+			started = make(chan struct{})
+			release = make(chan struct{})
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		first := make(chan error)
+		go func() {
+			first <- g.Do(ctx, time.Second, func(ctx context.Context) error {
+				close(started)
+				<-release
+				return ctx.Err()
+			})
+		}()
+		<-started
+
+		second := make(chan error)
+		go func() {
+			second <- g.Do(context.Background(), time.Second, func(ctx context.Context) error {
+				t.Error("call made while another was in flight")
+				return nil
+			})
+		}()
+
+		select {
+		case <-second:
+			t.Fatal("caller returned before the call in flight completed")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		cancel()
+
+		err := <-first
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		close(release)
+
+		require.NoError(t, <-second)
+	})
+
+	t.Run("call is bounded by the timeout", func(t *testing.T) {
+		var g flight.Group
+
+		err := g.Do(context.Background(), time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
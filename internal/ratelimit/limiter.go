@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+type (
+	// Limit is the maximum number of requests which can be made within an interval.
+	Limit struct {
+		Requests int
+		Interval time.Duration
+	}
+
+	// Limiter is a token bucket rate limiter, with a separate bucket for each method.
+	//
+	// Each bucket holds up to Limit.Requests tokens and is refilled evenly over Limit.Interval,
+	// so bursts of up to Limit.Requests are allowed.
+	Limiter struct {
+		clock        clockwork.Clock
+		limits       map[string]Limit
+		defaultLimit Limit
+
+		mu      sync.Mutex
+		buckets map[string]*bucket
+	}
+
+	bucket struct {
+		mu       sync.Mutex
+		capacity float64
+		// rate is the number of tokens added per second.
+		rate   float64
+		tokens float64
+		last   time.Time
+	}
+)
+
+// New creates a Limiter which applies limits to the specified methods, defaultLimit is applied to all other methods.
+func New(clock clockwork.Clock, limits map[string]Limit, defaultLimit Limit) *Limiter {
+	return &Limiter{
+		clock:        clock,
+		limits:       limits,
+		defaultLimit: defaultLimit,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+// Wait blocks until a request can be made to the specified method.
+// If ctx is done before then, the context's error is returned and the request is not counted towards the limit.
+func (l *Limiter) Wait(ctx context.Context, method string) error {
+	b := l.bucket(method)
+
+	wait := b.reserve(l.clock.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	// the timer is stopped if ctx is done first, so that abandoned waits don't leave timers behind.
+	timer := l.clock.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.Chan():
+		return nil
+	case <-ctx.Done():
+		b.release()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) bucket(method string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[method]; ok {
+		return b
+	}
+
+	limit, ok := l.limits[method]
+	if !ok {
+		limit = l.defaultLimit
+	}
+
+	b := &bucket{
+		capacity: float64(limit.Requests),
+		rate:     float64(limit.Requests) / limit.Interval.Seconds(),
+		tokens:   float64(limit.Requests),
+		last:     l.clock.Now(),
+	}
+	l.buckets[method] = b
+
+	return b
+}
+
+// reserve takes a token from the bucket, returning how long to wait until the token is available.
+// Tokens can be taken in advance, so that waiting requests are served in order.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+}
+
+// release returns a reserved token to the bucket.
+func (b *bucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.capacity, b.tokens+1)
+}
@@ -0,0 +1,135 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/ratelimit"
+)
+
+const (
+	method        = "private/create-order"
+	anotherMethod = "private/cancel-order"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Run("allows a burst of requests up to the limit", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		limiter := ratelimit.New(clock, map[string]ratelimit.Limit{
+			method: {Requests: 3, Interval: time.Second},
+		}, ratelimit.Limit{Requests: 1, Interval: time.Second})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, limiter.Wait(context.Background(), method))
+		}
+	})
+
+	t.Run("blocks until a token is available", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		limiter := ratelimit.New(clock, map[string]ratelimit.Limit{
+			method: {Requests: 2, Interval: time.Second},
+		}, ratelimit.Limit{Requests: 1, Interval: time.Second})
+
+		require.NoError(t, limiter.Wait(context.Background(), method))
+		require.NoError(t, limiter.Wait(context.Background(), method))
+
+		done := make(chan error)
+		go func() {
+			done <- limiter.Wait(context.Background(), method)
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(400 * time.Millisecond)
+
+		select {
+		case <-done:
+			t.Fatal("request allowed before a token was available")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		clock.Advance(100 * time.Millisecond)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("request not allowed once a token was available")
+		}
+	})
+
+	t.Run("tokens are refilled over the interval", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		limiter := ratelimit.New(clock, map[string]ratelimit.Limit{
+			method: {Requests: 1, Interval: time.Second},
+		}, ratelimit.Limit{Requests: 1, Interval: time.Second})
+
+		require.NoError(t, limiter.Wait(context.Background(), method))
+
+		clock.Advance(time.Second)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// a token is available, so the cancelled context is not checked.
+		require.NoError(t, limiter.Wait(ctx, method))
+	})
+
+	t.Run("methods are limited separately", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		limiter := ratelimit.New(clock, map[string]ratelimit.Limit{
+			method:        {Requests: 1, Interval: time.Second},
+			anotherMethod: {Requests: 1, Interval: time.Second},
+		}, ratelimit.Limit{Requests: 1, Interval: time.Second})
+
+		require.NoError(t, limiter.Wait(context.Background(), method))
+		require.NoError(t, limiter.Wait(context.Background(), anotherMethod))
+		require.NoError(t, limiter.Wait(context.Background(), "some other method"))
+	})
+
+	t.Run("returns context error if context is done while waiting", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		limiter := ratelimit.New(clock, nil, ratelimit.Limit{Requests: 1, Interval: time.Second})
+
+		require.NoError(t, limiter.Wait(context.Background(), method))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := limiter.Wait(ctx, method)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		// the timer of the cancelled request is stopped, so only the next request is waiting once it has reserved a token.
+		done := make(chan error)
+		go func() {
+			done <- limiter.Wait(context.Background(), method)
+		}()
+
+		clock.BlockUntil(1)
+
+		// the token reserved by the cancelled request is returned, so the next request only waits for the refill.
+		clock.Advance(999 * time.Millisecond)
+
+		select {
+		case <-done:
+			t.Fatal("request allowed before a token was available")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		clock.Advance(time.Millisecond)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("request not allowed once a token was available")
+		}
+	})
+}
@@ -18,6 +18,15 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes t as milliseconds since the epoch, the zero time is encoded as 0.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return []byte("0"), nil
+	}
+
+	return []byte(strconv.FormatInt(time.Time(t).UnixMilli(), 10)), nil
+}
+
 func (t *Time) Time() time.Time {
 	return time.Time(*t)
 }
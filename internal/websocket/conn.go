@@ -0,0 +1,626 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/id"
+)
+
+const (
+	subscriptionBufferSize = 100
+	closeTimeout           = time.Second
+	reconnectTimeout       = 30 * time.Second
+)
+
+type (
+	// Config is the configuration used to establish a websocket connection.
+	Config struct {
+		URL         string
+		IDGenerator id.IDGenerator
+		Clock       clockwork.Clock
+		// HeartbeatTimeout is the maximum time allowed between heartbeats from the exchange
+		// before the connection is considered stale, heartbeats are not monitored if 0.
+		HeartbeatTimeout time.Duration
+		// ReconnectMinBackoff & ReconnectMaxBackoff bound the delay between attempts to re-establish
+		// a lost connection, the connection is not re-established if ReconnectMaxBackoff is 0.
+		ReconnectMinBackoff time.Duration
+		ReconnectMaxBackoff time.Duration
+		// Authenticate is called each time the connection is established, before subscriptions are replayed.
+		Authenticate func(ctx context.Context, conn *Conn) error
+		// OnEvent is called when the state of the connection changes.
+		OnEvent func(Event)
+	}
+
+	// Conn is a websocket connection to the exchange.
+	// Requests are matched to their responses by ID and channel messages are routed to their subscription.
+	//
+	// If the underlying connection is lost it is re-established, re-authenticated
+	// and all active subscriptions are replayed.
+	Conn struct {
+		config Config
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		writeMu sync.Mutex
+		// reconnectMu serialises re-establishing the connection, whether it was lost or replaced by Reconnect.
+		reconnectMu sync.Mutex
+
+		mu            sync.Mutex
+		link          *link
+		pending       map[int64]chan Response
+		subscriptions map[string]*Subscription
+		err           error
+
+		done      chan struct{}
+		closeOnce sync.Once
+	}
+
+	// link is a single underlying connection, it is replaced each time the connection is re-established.
+	link struct {
+		conn       *gorilla.Conn
+		heartbeats chan struct{}
+
+		// err is the reason the link was lost, it must only be read once lost is closed.
+		err      error
+		lost     chan struct{}
+		lostOnce sync.Once
+	}
+
+	// Subscription is an active channel subscription on a connection.
+	Subscription struct {
+		Channel string
+
+		messages  chan SubscriptionResult
+		done      chan struct{}
+		closeOnce sync.Once
+		// dropping is set while messages are being dropped because the subscription is full.
+		dropping atomic.Bool
+	}
+)
+
+// Dial establishes a new websocket connection.
+func Dial(ctx context.Context, config Config) (*Conn, error) {
+	c := &Conn{
+		config:        config,
+		pending:       make(map[int64]chan Response),
+		subscriptions: make(map[string]*Subscription),
+		done:          make(chan struct{}),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	l, err := c.connect(ctx)
+	if err != nil {
+		c.cancel()
+		return nil, err
+	}
+
+	go c.maintain(l)
+
+	return c, nil
+}
+
+// Send writes the request to the connection and waits for the response with a matching ID.
+func (c *Conn) Send(ctx context.Context, req api.Request) (*Response, error) {
+	res := make(chan Response, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	l := c.link
+	c.pending[req.ID] = res
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, req.ID)
+		c.mu.Unlock()
+	}()
+
+	if err := c.write(l, req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case r := <-res:
+		if err := checkErrorResponse(r.Code); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-l.lost:
+		return nil, l.err
+	case <-c.done:
+		return nil, c.Err()
+	}
+}
+
+// Subscribe subscribes to the specified channel, messages pushed on the channel are delivered to the returned subscription.
+//
+// The subscription is replayed each time the connection is re-established.
+func (c *Conn) Subscribe(ctx context.Context, channel string) (*Subscription, error) {
+	sub := &Subscription{
+		Channel:  channel,
+		messages: make(chan SubscriptionResult, subscriptionBufferSize),
+		done:     make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	if _, ok := c.subscriptions[channel]; ok {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("already subscribed to %s", channel)
+	}
+	c.subscriptions[channel] = sub
+	c.mu.Unlock()
+
+	if _, err := c.Send(ctx, c.request(methodSubscribe, channel)); err != nil {
+		c.removeSubscription(channel)
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe unsubscribes from the specified channel and closes the subscription.
+func (c *Conn) Unsubscribe(ctx context.Context, channel string) error {
+	defer c.removeSubscription(channel)
+
+	if _, err := c.Send(ctx, c.request(methodUnsubscribe, channel)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reconnect replaces the underlying connection with a new one, which is authenticated & has all active subscriptions
+// replayed before the previous connection is closed. The previous connection is kept if a new one cannot be established.
+//
+// Requests which are waiting for a response on the previous connection fail once it is closed.
+func (c *Conn) Reconnect(ctx context.Context) error {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	previous := c.currentLink()
+
+	if _, err := c.establish(ctx); err != nil {
+		c.mu.Lock()
+		c.link = previous
+		c.mu.Unlock()
+
+		return err
+	}
+
+	c.sendClose(previous)
+	previous.lose(fmt.Errorf("%w: replaced by a new connection", errors.ErrConnectionClosed))
+
+	return nil
+}
+
+// Close closes the connection along with all of its subscriptions.
+func (c *Conn) Close() error {
+	c.sendClose(c.currentLink())
+	c.closeWithError(errors.ErrConnectionClosed)
+
+	return nil
+}
+
+// Done returns a channel that is closed once the connection is closed.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the reason the connection was closed, nil if it is still open.
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Messages returns the channel on which subscription messages are delivered.
+func (s *Subscription) Messages() <-chan SubscriptionResult {
+	return s.messages
+}
+
+// Done returns a channel that is closed once the subscription is closed.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// connect establishes a new underlying connection and authenticates it.
+func (c *Conn) connect(ctx context.Context) (*link, error) {
+	conn, _, err := gorilla.DefaultDialer.DialContext(ctx, c.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	l := &link{
+		conn:       conn,
+		heartbeats: make(chan struct{}, 1),
+		lost:       make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		l.lose(err)
+		return nil, err
+	}
+	c.link = l
+	c.mu.Unlock()
+
+	go c.read(l)
+
+	if c.config.HeartbeatTimeout > 0 {
+		go c.monitorHeartbeats(l)
+	}
+
+	if c.config.Authenticate != nil {
+		if err := c.config.Authenticate(ctx, c); err != nil {
+			l.lose(err)
+			return nil, err
+		}
+	}
+
+	c.emit(Event{Type: EventConnected})
+
+	return l, nil
+}
+
+// maintain re-establishes the connection each time the underlying connection is lost,
+// the connection is closed if it is not to be re-established.
+func (c *Conn) maintain(l *link) {
+	for {
+		select {
+		case <-l.lost:
+		case <-c.done:
+			return
+		}
+
+		// the underlying connection was replaced by Reconnect, so the new connection is maintained instead.
+		if current := c.currentLink(); current != l && current.alive() {
+			l = current
+			continue
+		}
+
+		if c.config.ReconnectMaxBackoff <= 0 {
+			c.closeWithError(l.err)
+			return
+		}
+
+		next, err := c.reconnect(l)
+		if err != nil {
+			c.closeWithError(err)
+			return
+		}
+
+		l = next
+	}
+}
+
+// reconnect attempts to re-establish a lost connection until it succeeds or the connection is closed.
+func (c *Conn) reconnect(lost *link) (*link, error) {
+	cause := lost.err
+
+	for attempt := 0; ; attempt++ {
+		c.emit(Event{Type: EventReconnecting, Err: cause})
+
+		select {
+		case <-c.config.Clock.After(c.backoff(attempt)):
+		case <-c.done:
+			return nil, c.Err()
+		}
+
+		l, err := c.reestablish(lost)
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		return l, nil
+	}
+}
+
+// reestablish connects and replays all active subscriptions on the new connection,
+// unless the lost connection has been replaced by Reconnect meanwhile.
+func (c *Conn) reestablish(lost *link) (*link, error) {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	if current := c.currentLink(); current != lost && current.alive() {
+		return current, nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, reconnectTimeout)
+	defer cancel()
+
+	return c.establish(ctx)
+}
+
+// establish connects and replays all active subscriptions on the new connection.
+func (c *Conn) establish(ctx context.Context) (*link, error) {
+	l, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.resubscribe(ctx); err != nil {
+		l.lose(err)
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (c *Conn) resubscribe(ctx context.Context) error {
+	c.mu.Lock()
+	channels := make([]string, 0, len(c.subscriptions))
+	for channel := range c.subscriptions {
+		channels = append(channels, channel)
+	}
+	c.mu.Unlock()
+
+	if len(channels) > 0 {
+		if _, err := c.Send(ctx, c.request(methodSubscribe, channels...)); err != nil {
+			return fmt.Errorf("failed to resubscribe: %w", err)
+		}
+	}
+
+	c.emit(Event{Type: EventResubscribed})
+
+	return nil
+}
+
+// backoff returns the delay before the specified reconnect attempt, doubling with each attempt up to the maximum.
+// Half of the delay is random so that clients which are disconnected together do not reconnect together.
+func (c *Conn) backoff(attempt int) time.Duration {
+	backoff := c.config.ReconnectMaxBackoff
+	if attempt < 32 {
+		if b := c.config.ReconnectMinBackoff << attempt; b > 0 && b < backoff {
+			backoff = b
+		}
+	}
+
+	half := int64(backoff / 2)
+
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// currentLink returns the underlying connection requests are currently sent on.
+func (c *Conn) currentLink() *link {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.link
+}
+
+// sendClose writes a close message to the underlying connection,
+// which is best effort as the connection may already be broken.
+func (c *Conn) sendClose(l *link) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = l.conn.WriteControl(
+		gorilla.CloseMessage,
+		gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""),
+		time.Now().Add(closeTimeout),
+	)
+}
+
+func (c *Conn) request(method string, channels ...string) api.Request {
+	return api.Request{
+		ID:     c.config.IDGenerator.Generate(),
+		Method: method,
+		Nonce:  c.config.Clock.Now().UnixMilli(),
+		Params: map[string]interface{}{
+			"channels": channels,
+		},
+	}
+}
+
+func (c *Conn) write(l *link, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := l.conn.WriteMessage(gorilla.TextMessage, b); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) read(l *link) {
+	for {
+		_, b, err := l.conn.ReadMessage()
+		if err != nil {
+			l.lose(fmt.Errorf("%w: %v", errors.ErrConnectionClosed, err))
+			return
+		}
+
+		var res Response
+		if err := json.Unmarshal(b, &res); err != nil {
+			// messages which cannot be parsed are ignored rather than dropping the connection.
+			continue
+		}
+
+		c.handle(l, res)
+	}
+}
+
+func (c *Conn) handle(l *link, res Response) {
+	if res.Method == methodHeartbeat {
+		c.respondHeartbeat(l, res.ID)
+		return
+	}
+
+	if id, err := res.ID.Int64(); err == nil {
+		c.mu.Lock()
+		pending, ok := c.pending[id]
+		c.mu.Unlock()
+
+		if ok {
+			select {
+			case pending <- res:
+			default:
+			}
+		}
+	}
+
+	if res.Method != methodSubscribe || len(res.Result) == 0 {
+		return
+	}
+
+	var result SubscriptionResult
+	if err := json.Unmarshal(res.Result, &result); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	sub, ok := c.subscriptions[result.Subscription]
+	c.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// the reader must never block on a slow subscriber, otherwise heartbeats would go unanswered,
+	// so messages are dropped once the subscription's buffer is full.
+	// The event is only emitted for the first message dropped, until messages are delivered again.
+	select {
+	case sub.messages <- result:
+		sub.dropping.Store(false)
+	case <-sub.done:
+	default:
+		if !sub.dropping.Swap(true) {
+			c.emit(Event{Type: EventMessageDropped, Err: fmt.Errorf("%w: %s", errors.ErrMessageDropped, sub.Channel)})
+		}
+	}
+}
+
+// respondHeartbeat echoes the heartbeat ID back to the exchange, otherwise the connection is dropped.
+//
+// Method: public/respond-heartbeat
+func (c *Conn) respondHeartbeat(l *link, id json.Number) {
+	select {
+	case l.heartbeats <- struct{}{}:
+	default:
+	}
+
+	if err := c.write(l, heartbeatResponse{ID: id, Method: methodRespondHeartbeat}); err != nil {
+		l.lose(fmt.Errorf("%w: %v", errors.ErrConnectionClosed, err))
+	}
+}
+
+// monitorHeartbeats drops the underlying connection if a heartbeat is not received within the heartbeat timeout.
+func (c *Conn) monitorHeartbeats(l *link) {
+	for {
+		select {
+		case <-l.heartbeats:
+		case <-c.config.Clock.After(c.config.HeartbeatTimeout):
+			c.emit(Event{Type: EventHeartbeatMissed, Err: errors.ErrHeartbeatMissed})
+			l.lose(errors.ErrHeartbeatMissed)
+			return
+		case <-l.lost:
+			return
+		}
+	}
+}
+
+func (c *Conn) emit(event Event) {
+	if c.config.OnEvent != nil {
+		c.config.OnEvent(event)
+	}
+}
+
+func (c *Conn) removeSubscription(channel string) {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[channel]
+	delete(c.subscriptions, channel)
+	c.mu.Unlock()
+
+	if ok {
+		sub.close()
+	}
+}
+
+func (c *Conn) closeWithError(err error) {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		l := c.link
+		subscriptions := c.subscriptions
+		c.subscriptions = make(map[string]*Subscription)
+		c.mu.Unlock()
+
+		c.emit(Event{Type: EventDisconnected, Err: err})
+
+		close(c.done)
+		c.cancel()
+
+		for _, sub := range subscriptions {
+			sub.close()
+		}
+
+		l.lose(err)
+	})
+}
+
+// alive reports whether the underlying connection has not been lost.
+func (l *link) alive() bool {
+	select {
+	case <-l.lost:
+		return false
+	default:
+		return true
+	}
+}
+
+// lose closes the underlying connection, recording the reason it was lost.
+func (l *link) lose(err error) {
+	l.lostOnce.Do(func() {
+		l.err = err
+		close(l.lost)
+		_ = l.conn.Close()
+	})
+}
+
+func checkErrorResponse(responseCode json.Number) error {
+	if responseCode == "" {
+		return nil
+	}
+
+	code, err := responseCode.Int64()
+	if err != nil {
+		return errors.ResponseError{
+			Err: fmt.Errorf("invalid response code: %v", responseCode),
+		}
+	}
+
+	return errors.NewResponseError(0, code)
+}
@@ -0,0 +1,51 @@
+package websocket
+
+import "encoding/json"
+
+const (
+	methodSubscribe        = "subscribe"
+	methodUnsubscribe      = "unsubscribe"
+	methodHeartbeat        = "public/heartbeat"
+	methodRespondHeartbeat = "public/respond-heartbeat"
+
+	EventConnected       EventType = "CONNECTED"
+	EventReconnecting    EventType = "RECONNECTING"
+	EventResubscribed    EventType = "RESUBSCRIBED"
+	EventDisconnected    EventType = "DISCONNECTED"
+	EventHeartbeatMissed EventType = "HEARTBEAT_MISSED"
+	EventMessageDropped  EventType = "MESSAGE_DROPPED"
+)
+
+type (
+	// EventType is the type of event emitted for a connection.
+	EventType string
+
+	// Event is emitted when the state of a connection changes.
+	Event struct {
+		Type EventType
+		Err  error
+	}
+
+	// Response is the common envelope of every message received over the websocket.
+	Response struct {
+		ID      json.Number     `json:"id"`
+		Method  string          `json:"method"`
+		Code    json.Number     `json:"code"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+
+	// SubscriptionResult is the result of a message pushed for a channel subscription.
+	SubscriptionResult struct {
+		InstrumentName string          `json:"instrument_name"`
+		Subscription   string          `json:"subscription"`
+		Channel        string          `json:"channel"`
+		Data           json.RawMessage `json:"data"`
+	}
+
+	// heartbeatResponse is sent in response to a heartbeat from the exchange.
+	heartbeatResponse struct {
+		ID     json.Number `json:"id"`
+		Method string      `json:"method"`
+	}
+)
@@ -0,0 +1,274 @@
+package cdcexchange
+
+import (
+	"context"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+const (
+	// maxHistoryWindow is the maximum duration between the start & end of a history request accepted by the Exchange.
+	maxHistoryWindow = 24 * time.Hour
+
+	// defaultPageSize is the page size used by the Exchange if a page size is not specified.
+	defaultPageSize = 20
+)
+
+type (
+	// OrderIterator iterates over orders, fetching each page from the Exchange as it is needed.
+	//
+	//	it := client.IterateOrderHistory(ctx, req)
+	//	for it.Next() {
+	//		order := it.Order()
+	//	}
+	//	if err := it.Err(); err != nil {
+	//		return err
+	//	}
+	OrderIterator struct {
+		pages  pager
+		orders []Order
+		order  Order
+	}
+
+	// TradeIterator iterates over trades, fetching each page from the Exchange as it is needed.
+	//
+	//	it := client.IterateTrades(ctx, req)
+	//	for it.Next() {
+	//		trade := it.Trade()
+	//	}
+	//	if err := it.Err(); err != nil {
+	//		return err
+	//	}
+	TradeIterator struct {
+		pages  pager
+		trades []Trade
+		trade  Trade
+	}
+
+	// pager walks the pages of each window of a paginated request.
+	pager struct {
+		ctx     context.Context
+		windows []window
+		window  int
+		page    int
+		done    bool
+		err     error
+		// fetch fetches a page of a window, returning whether there are more pages in the window.
+		fetch func(ctx context.Context, w window, page int) (bool, error)
+	}
+
+	// window is the time range of a request, the zero window leaves the range to the Exchange's defaults.
+	window struct {
+		start time.Time
+		end   time.Time
+	}
+)
+
+// Next advances the iterator to the next order, fetching the next page if needed.
+// It returns false once all orders have been iterated, or an error occurs.
+func (it *OrderIterator) Next() bool {
+	for len(it.orders) == 0 {
+		if !it.pages.next() {
+			return false
+		}
+	}
+
+	it.order, it.orders = it.orders[0], it.orders[1:]
+	return true
+}
+
+// Order returns the current order.
+func (it *OrderIterator) Order() Order {
+	return it.order
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *OrderIterator) Err() error {
+	return it.pages.err
+}
+
+// Next advances the iterator to the next trade, fetching the next page if needed.
+// It returns false once all trades have been iterated, or an error occurs.
+func (it *TradeIterator) Next() bool {
+	for len(it.trades) == 0 {
+		if !it.pages.next() {
+			return false
+		}
+	}
+
+	it.trade, it.trades = it.trades[0], it.trades[1:]
+	return true
+}
+
+// Trade returns the current trade.
+func (it *TradeIterator) Trade() Trade {
+	return it.trade
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *TradeIterator) Err() error {
+	return it.pages.err
+}
+
+// IterateOrderHistory iterates over the order history for a particular instrument, starting from req.Page.
+//
+// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+// req.End defaults to now if only req.Start is set.
+//
+// Method: private/get-order-history
+func (c *client) IterateOrderHistory(ctx context.Context, req GetOrderHistoryRequest) *OrderIterator {
+	return c.iterateOrderHistory(ctx, methodGetOrderHistory, req)
+}
+
+// IterateOpenOrders iterates over all open orders for a particular instrument, starting from req.Page.
+//
+// Method: private/get-open-orders
+func (c *client) IterateOpenOrders(ctx context.Context, req GetOpenOrdersRequest) *OrderIterator {
+	return c.iterateOpenOrders(ctx, methodGetOpenOrders, req)
+}
+
+// IterateTrades iterates over all executed trades for a particular instrument, starting from req.Page.
+//
+// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+// req.End defaults to now if only req.Start is set.
+//
+// Method: private/get-trades
+func (c *client) IterateTrades(ctx context.Context, req GetTradesRequest) *TradeIterator {
+	return c.iterateTrades(ctx, methodGetTrades, req)
+}
+
+// iterateOrderHistory iterates over order history from the specified method (spot or margin).
+func (c *client) iterateOrderHistory(ctx context.Context, method string, req GetOrderHistoryRequest) *OrderIterator {
+	it := &OrderIterator{}
+	it.pages = c.newPager(ctx, req.Start, req.End, req.Page, func(ctx context.Context, w window, page int) (bool, error) {
+		req.Start, req.End, req.Page = w.start, w.end, page
+
+		res, err := c.getOrderHistory(ctx, method, req)
+		if err != nil {
+			return false, err
+		}
+		it.orders = res.OrderList
+		return res.HasMore, nil
+	})
+
+	return it
+}
+
+// iterateOpenOrders iterates over open orders from the specified method (spot or margin).
+func (c *client) iterateOpenOrders(ctx context.Context, method string, req GetOpenOrdersRequest) *OrderIterator {
+	it := &OrderIterator{}
+	it.pages = c.newPager(ctx, time.Time{}, time.Time{}, req.Page, func(ctx context.Context, _ window, page int) (bool, error) {
+		req.Page = page
+
+		res, err := c.getOpenOrders(ctx, method, req)
+		if err != nil {
+			return false, err
+		}
+		it.orders = res.OrderList
+		return res.HasMore, nil
+	})
+
+	return it
+}
+
+// iterateTrades iterates over executed trades from the specified method (spot or margin).
+func (c *client) iterateTrades(ctx context.Context, method string, req GetTradesRequest) *TradeIterator {
+	it := &TradeIterator{}
+	it.pages = c.newPager(ctx, req.Start, req.End, req.Page, func(ctx context.Context, w window, page int) (bool, error) {
+		req.Start, req.End, req.Page = w.start, w.end, page
+
+		res, err := c.getTrades(ctx, method, req)
+		if err != nil {
+			return false, err
+		}
+		it.trades = res.TradeList
+		return res.HasMore, nil
+	})
+
+	return it
+}
+
+// newPager creates a pager over the windows between start & end, beginning at the specified page of the first window.
+func (c *client) newPager(ctx context.Context, start, end time.Time, page int, fetch func(context.Context, window, int) (bool, error)) pager {
+	p := pager{
+		ctx:   ctx,
+		page:  page,
+		fetch: fetch,
+	}
+
+	switch {
+	case start.IsZero() && end.IsZero():
+		p.windows = []window{{}}
+		return p
+	case start.IsZero():
+		start = end.Add(-maxHistoryWindow)
+	case end.IsZero():
+		end = c.clock.Now()
+	}
+
+	if end.Before(start) {
+		p.err = errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+		return p
+	}
+
+	p.windows = splitWindows(start, end)
+	return p
+}
+
+// splitWindows splits the range between start & end into consecutive windows accepted by the Exchange.
+// Timestamps are inclusive, so each window starts a millisecond after the end of the previous window.
+func splitWindows(start, end time.Time) []window {
+	var windows []window
+	for {
+		windowEnd := start.Add(maxHistoryWindow)
+		if !windowEnd.Before(end) {
+			return append(windows, window{start: start, end: end})
+		}
+
+		windows = append(windows, window{start: start, end: windowEnd})
+		start = windowEnd.Add(time.Millisecond)
+	}
+}
+
+// next fetches the next page, returning false once all pages have been fetched or an error occurs.
+// A page may be empty if it is the last page of a window.
+func (p *pager) next() bool {
+	if p.err != nil || p.done {
+		return false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	hasMore, err := p.fetch(p.ctx, p.windows[p.window], p.page)
+	if err != nil {
+		// a cancelled context is reported as is, rather than as the failed request it caused.
+		if ctxErr := p.ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		p.err = err
+		return false
+	}
+
+	if hasMore {
+		p.page++
+		return true
+	}
+
+	p.window++
+	p.page = 0
+	p.done = p.window == len(p.windows)
+	return true
+}
+
+// pageSize returns the page size used by the Exchange for the requested page size.
+func pageSize(size int) int {
+	if size == 0 {
+		return defaultPageSize
+	}
+
+	return size
+}
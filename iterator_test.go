@@ -0,0 +1,272 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+// pageRequest is the window & page of a paginated request received by the test server.
+type pageRequest struct {
+	start int64
+	end   int64
+	page  int
+}
+
+// newPageServer creates a server which responds to each paginated request with the next response in responses.
+func newPageServer(t *testing.T, method string, responses []string) (*httptest.Server, func() []pageRequest) {
+	var (
+		mu       sync.Mutex
+		requests []pageRequest
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, method)
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		req := pageRequest{page: int(body.Params["page"].(float64))}
+		if start, ok := body.Params["start_ts"]; ok {
+			req.start = int64(start.(float64))
+		}
+		if end, ok := body.Params["end_ts"]; ok {
+			req.end = int64(end.(float64))
+		}
+
+		require.Less(t, len(requests), len(responses), "unexpected request")
+		res := responses[len(requests)]
+		requests = append(requests, req)
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	return s, func() []pageRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return requests
+	}
+}
+
+// page creates a response containing a list of items with the specified IDs.
+func page(list, idField string, ids ...string) string {
+	items := make([]string, len(ids))
+	for i, id := range ids {
+		items[i] = fmt.Sprintf(`{%q:%q}`, idField, id)
+	}
+
+	return fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{%q:[%s]}}`, list, strings.Join(items, ","))
+}
+
+func tradesPage(ids ...string) string {
+	return page("trade_list", "trade_id", ids...)
+}
+
+func ordersPage(ids ...string) string {
+	return page("order_list", "order_id", ids...)
+}
+
+func openOrdersPage(count int, ids ...string) string {
+	return strings.Replace(ordersPage(ids...), `"result":{`, fmt.Sprintf(`"result":{"count":%d,`, count), 1)
+}
+
+func newIteratorClient(t *testing.T, s *httptest.Server, clock clockwork.Clock) cdcexchange.CryptoDotComExchange {
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRateLimitDisabled(),
+	)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestClient_IterateTrades(t *testing.T) {
+	var (
+		start = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = start.Add(50 * time.Hour)
+	)
+
+	s, requests := newPageServer(t, cdcexchange.MethodGetTrades, []string{
+		// first window
+		tradesPage("1", "2"),
+		tradesPage("3"),
+		// second window
+		tradesPage(),
+		// third window
+		tradesPage("4", "5"),
+		tradesPage(),
+	})
+	client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+	it := client.IterateTrades(context.Background(), cdcexchange.GetTradesRequest{
+		InstrumentName: "CRO_USDT",
+		Start:          start,
+		End:            end,
+		PageSize:       2,
+	})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Trade().TradeID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+
+	var (
+		secondStart = start.Add(24*time.Hour + time.Millisecond)
+		thirdStart  = start.Add(48*time.Hour + 2*time.Millisecond)
+	)
+	assert.Equal(t, []pageRequest{
+		{start: start.UnixMilli(), end: start.Add(24 * time.Hour).UnixMilli(), page: 0},
+		{start: start.UnixMilli(), end: start.Add(24 * time.Hour).UnixMilli(), page: 1},
+		{start: secondStart.UnixMilli(), end: secondStart.Add(24 * time.Hour).UnixMilli(), page: 0},
+		{start: thirdStart.UnixMilli(), end: end.UnixMilli(), page: 0},
+		{start: thirdStart.UnixMilli(), end: end.UnixMilli(), page: 1},
+	}, requests())
+
+	// the iterator is exhausted.
+	assert.False(t, it.Next())
+}
+
+func TestClient_IterateTrades_DefaultEnd(t *testing.T) {
+	now := time.Date(2022, 1, 2, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+
+	s, requests := newPageServer(t, cdcexchange.MethodGetMarginTrades, []string{
+		tradesPage("1"),
+	})
+	client := newIteratorClient(t, s, clockwork.NewFakeClockAt(now))
+
+	it := client.IterateMarginTrades(context.Background(), cdcexchange.GetTradesRequest{Start: start})
+
+	require.True(t, it.Next())
+	assert.Equal(t, "1", it.Trade().TradeID)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []pageRequest{{start: start.UnixMilli(), end: now.UnixMilli()}}, requests())
+}
+
+func TestClient_IterateOrderHistory(t *testing.T) {
+	s, requests := newPageServer(t, cdcexchange.MethodGetOrderHistory, []string{
+		ordersPage("1", "2"),
+		ordersPage("3", "4"),
+		ordersPage(),
+	})
+	client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+	// without a range, the Exchange's default range is used.
+	it := client.IterateOrderHistory(context.Background(), cdcexchange.GetOrderHistoryRequest{PageSize: 2, Page: 1})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Order().OrderID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+	assert.Equal(t, []pageRequest{{page: 1}, {page: 2}, {page: 3}}, requests())
+}
+
+func TestClient_IterateOpenOrders(t *testing.T) {
+	orders := make([]string, 20)
+	for i := range orders {
+		orders[i] = fmt.Sprintf("%d", i)
+	}
+
+	s, requests := newPageServer(t, cdcexchange.MethodGetOpenOrders, []string{
+		openOrdersPage(21, orders...),
+		openOrdersPage(21, "20"),
+	})
+	client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+	it := client.IterateOpenOrders(context.Background(), cdcexchange.GetOpenOrdersRequest{})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Order().OrderID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, append(orders, "20"), ids)
+	assert.Equal(t, []pageRequest{{page: 0}, {page: 1}}, requests())
+}
+
+func TestClient_Iterate_Error(t *testing.T) {
+	t.Run("returns error when end is before start", func(t *testing.T) {
+		s, requests := newPageServer(t, cdcexchange.MethodGetTrades, nil)
+		client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+		start := time.Now()
+		it := client.IterateTrades(context.Background(), cdcexchange.GetTradesRequest{
+			Start: start,
+			End:   start.Add(-time.Second),
+		})
+
+		assert.False(t, it.Next())
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}, it.Err())
+		assert.Empty(t, requests())
+	})
+
+	t.Run("returns error given error response", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+			_, err := w.Write([]byte(`{"id":0,"method":"","code":10003}`))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+		client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+		it := client.IterateOrderHistory(context.Background(), cdcexchange.GetOrderHistoryRequest{})
+
+		assert.False(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), cdcerrors.ErrIllegalIP))
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		s, requests := newPageServer(t, cdcexchange.MethodGetTrades, []string{
+			tradesPage("1", "2"),
+			tradesPage("3"),
+		})
+		client := newIteratorClient(t, s, clockwork.NewFakeClock())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		it := client.IterateTrades(ctx, cdcexchange.GetTradesRequest{PageSize: 2})
+
+		require.True(t, it.Next())
+		cancel()
+
+		// the fetched page is drained, but the next page is not fetched.
+		require.True(t, it.Next())
+		assert.False(t, it.Next())
+
+		assert.True(t, errors.Is(it.Err(), context.Canceled))
+		assert.Len(t, requests(), 1)
+	})
+}
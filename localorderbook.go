@@ -0,0 +1,391 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+// half is used to find the mid price exactly, as decimals are not divided.
+var half = decimal.New(5, -1)
+
+type (
+	// BookLevel is a single price level of an order book.
+	BookLevel struct {
+		// Price is the price of the level.
+		Price decimal.Decimal
+		// Quantity is the total quantity available at the price.
+		Quantity decimal.Decimal
+		// NumberOfOrders is the number of orders at the price.
+		NumberOfOrders int64
+	}
+
+	// LocalOrderBook is an in-memory order book for a single instrument, seeded from public/get-book
+	// and kept current from book channel updates.
+	//
+	// Updates which do not follow on from the previous update (i.e. a sequence gap) cause the book to be
+	// resynced from public/get-book, the book is out of sync until the resync succeeds.
+	//
+	// A LocalOrderBook is safe for concurrent use.
+	LocalOrderBook struct {
+		api        CommonAPI
+		ws         MarketWebsocket
+		instrument string
+		depth      int
+
+		mu        sync.RWMutex
+		bids      []BookLevel
+		asks      []BookLevel
+		updateID  int64
+		timestamp time.Time
+		inSync    bool
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+)
+
+// NewLocalOrderBook creates a LocalOrderBook for a particular instrument and depth, subscribing to book updates on ws
+// and seeding the book with a snapshot from api.
+//
+// The book stops updating once it is closed or the websocket connection is closed.
+func NewLocalOrderBook(ctx context.Context, api CommonAPI, ws MarketWebsocket, instrument string, depth int) (*LocalOrderBook, error) {
+	switch {
+	case api == nil:
+		return nil, errors.InvalidParameterError{Parameter: "api", Reason: "cannot be empty"}
+	case ws == nil:
+		return nil, errors.InvalidParameterError{Parameter: "ws", Reason: "cannot be empty"}
+	case instrument == "":
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	case depth <= 0:
+		return nil, errors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"}
+	}
+
+	// subscribe before taking the snapshot so that no updates are missed in between.
+	updates, err := ws.SubscribeBook(ctx, instrument, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to book: %w", err)
+	}
+
+	b := &LocalOrderBook{
+		api:        api,
+		ws:         ws,
+		instrument: instrument,
+		depth:      depth,
+		done:       make(chan struct{}),
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	if err := b.resync(ctx); err != nil {
+		b.cancel()
+		_ = ws.UnsubscribeBook(context.Background(), instrument, depth)
+		return nil, err
+	}
+
+	go b.run(updates)
+
+	return b, nil
+}
+
+// Instrument returns the instrument of the book.
+func (b *LocalOrderBook) Instrument() string {
+	return b.instrument
+}
+
+// Bids returns the bid levels of the book, sorted by descending price.
+func (b *LocalOrderBook) Bids() []BookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]BookLevel(nil), b.bids...)
+}
+
+// Asks returns the ask levels of the book, sorted by ascending price.
+func (b *LocalOrderBook) Asks() []BookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]BookLevel(nil), b.asks...)
+}
+
+// BestBid returns the highest bid, false is returned if there are no bids.
+func (b *LocalOrderBook) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+
+	return b.bids[0], true
+}
+
+// BestAsk returns the lowest ask, false is returned if there are no asks.
+func (b *LocalOrderBook) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+
+	return b.asks[0], true
+}
+
+// Spread returns the difference between the best ask and best bid prices,
+// false is returned if either side of the book is empty.
+func (b *LocalOrderBook) Spread() (decimal.Decimal, bool) {
+	bid, ask, ok := b.top()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return ask.Price.Sub(bid.Price), true
+}
+
+// Mid returns the price halfway between the best bid and best ask prices,
+// false is returned if either side of the book is empty.
+func (b *LocalOrderBook) Mid() (decimal.Decimal, bool) {
+	bid, ask, ok := b.top()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return bid.Price.Add(ask.Price).Mul(half), true
+}
+
+// DepthAtPrice returns the quantity available at exactly the specified price on one side of the book,
+// bids for OrderSideBuy & asks for OrderSideSell.
+func (b *LocalOrderBook) DepthAtPrice(side OrderSide, price decimal.Decimal) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, level := range b.levels(side) {
+		if level.Price == price {
+			return level.Quantity
+		}
+	}
+
+	return decimal.Zero
+}
+
+// CumulativeVolume returns the total quantity available at prices at or better than the specified price
+// on one side of the book, bids for OrderSideBuy & asks for OrderSideSell.
+func (b *LocalOrderBook) CumulativeVolume(side OrderSide, price decimal.Decimal) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	volume := decimal.Zero
+	for _, level := range b.levels(side) {
+		if side == OrderSideBuy && level.Price.LessThan(price) || side == OrderSideSell && level.Price.GreaterThan(price) {
+			break
+		}
+		volume = volume.Add(level.Quantity)
+	}
+
+	return volume
+}
+
+// UpdateID returns the ID of the last update applied to the book.
+func (b *LocalOrderBook) UpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.updateID
+}
+
+// Timestamp returns the time of the last update applied to the book.
+func (b *LocalOrderBook) Timestamp() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.timestamp
+}
+
+// InSync returns false if a sequence gap has been detected and the book has not yet been resynced.
+func (b *LocalOrderBook) InSync() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.inSync
+}
+
+// Done returns a channel that is closed once the book stops updating.
+func (b *LocalOrderBook) Done() <-chan struct{} {
+	return b.done
+}
+
+// Close stops the book from updating and unsubscribes from book updates.
+func (b *LocalOrderBook) Close() error {
+	b.cancel()
+
+	if err := b.ws.UnsubscribeBook(context.Background(), b.instrument, b.depth); err != nil {
+		return fmt.Errorf("failed to unsubscribe from book: %w", err)
+	}
+
+	return nil
+}
+
+func (b *LocalOrderBook) run(updates <-chan BookResult) {
+	defer close(b.done)
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			b.handle(update)
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+// handle applies the update to the book, resyncing the book if the update does not follow on from the previous update.
+func (b *LocalOrderBook) handle(update BookResult) {
+	if b.apply(update) {
+		return
+	}
+
+	// a failed resync is retried on the next update.
+	if err := b.resync(b.ctx); err != nil {
+		return
+	}
+
+	// the update may follow on from the resynced book, it is discarded if the book is already past it.
+	b.apply(update)
+}
+
+// apply applies the update to the book, false is returned if the book must be resynced.
+//
+// Updates without a previous update ID are snapshots which replace the book,
+// updates which precede the current state of the book are discarded.
+// If the book has no update ID (i.e. the snapshot had none), the first update applied sets the baseline
+// which the following updates are checked against.
+func (b *LocalOrderBook) apply(update BookResult) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.inSync {
+		return false
+	}
+
+	if update.PreviousUpdateID == 0 {
+		if update.UpdateID == 0 || update.UpdateID > b.updateID {
+			b.replace(update)
+		}
+		return true
+	}
+
+	if b.updateID != 0 {
+		if update.UpdateID <= b.updateID {
+			return true
+		}
+
+		if update.PreviousUpdateID != b.updateID {
+			b.inSync = false
+			return false
+		}
+	}
+
+	b.bids = mergeLevels(b.bids, update.Bids, b.depth, higher)
+	b.asks = mergeLevels(b.asks, update.Asks, b.depth, lower)
+	b.updateID = update.UpdateID
+	b.timestamp = update.Timestamp.Time()
+
+	return true
+}
+
+// resync replaces the book with a snapshot from public/get-book.
+func (b *LocalOrderBook) resync(ctx context.Context) error {
+	snapshot, err := b.api.GetBook(ctx, b.instrument, b.depth)
+	if err != nil {
+		return fmt.Errorf("failed to get book: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.replace(*snapshot)
+	b.inSync = true
+
+	return nil
+}
+
+func (b *LocalOrderBook) replace(snapshot BookResult) {
+	b.bids = mergeLevels(nil, snapshot.Bids, b.depth, higher)
+	b.asks = mergeLevels(nil, snapshot.Asks, b.depth, lower)
+	b.updateID = snapshot.UpdateID
+	b.timestamp = snapshot.Timestamp.Time()
+}
+
+// top returns the best bid and ask, false is returned if either side of the book is empty.
+func (b *LocalOrderBook) top() (BookLevel, BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return BookLevel{}, BookLevel{}, false
+	}
+
+	return b.bids[0], b.asks[0], true
+}
+
+func (b *LocalOrderBook) levels(side OrderSide) []BookLevel {
+	if side == OrderSideBuy {
+		return b.bids
+	}
+	return b.asks
+}
+
+// mergeLevels sets the raw [price, quantity, number of orders] entries on levels,
+// removing any with a quantity of 0, and returns the best depth levels sorted best first.
+func mergeLevels(levels []BookLevel, entries [][]decimal.Decimal, depth int, better func(a, b decimal.Decimal) bool) []BookLevel {
+	byPrice := make(map[decimal.Decimal]BookLevel, len(levels)+len(entries))
+	for _, level := range levels {
+		byPrice[level.Price] = level
+	}
+
+	for _, entry := range entries {
+		if len(entry) < 2 {
+			continue
+		}
+
+		level := BookLevel{Price: entry[0], Quantity: entry[1]}
+		if len(entry) > 2 {
+			level.NumberOfOrders = entry[2].IntPart()
+		}
+
+		if level.Quantity.IsZero() {
+			delete(byPrice, level.Price)
+			continue
+		}
+		byPrice[level.Price] = level
+	}
+
+	merged := make([]BookLevel, 0, len(byPrice))
+	for _, level := range byPrice {
+		merged = append(merged, level)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return better(merged[i].Price, merged[j].Price)
+	})
+
+	if len(merged) > depth {
+		merged = merged[:depth]
+	}
+
+	return merged
+}
+
+func higher(x, y decimal.Decimal) bool { return x.GreaterThan(y) }
+
+func lower(x, y decimal.Decimal) bool { return x.LessThan(y) }
@@ -0,0 +1,474 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+type (
+	// fakeBookAPI returns each snapshot in turn from GetBook, repeating the last.
+	fakeBookAPI struct {
+		cdcexchange.CommonAPI
+
+		mu        sync.Mutex
+		snapshots []cdcexchange.BookResult
+		err       error
+		calls     int
+	}
+
+	// fakeBookWebsocket delivers updates sent on its channel to the book subscription.
+	fakeBookWebsocket struct {
+		cdcexchange.MarketWebsocket
+
+		updates      chan cdcexchange.BookResult
+		subscribeErr error
+
+		mu           sync.Mutex
+		unsubscribed bool
+	}
+)
+
+// d parses a decimal, keeping book levels short enough to read.
+var d = decimal.MustParse
+
+func (f *fakeBookAPI) GetBook(context.Context, string, int) (*cdcexchange.BookResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	i := f.calls
+	if i >= len(f.snapshots) {
+		i = len(f.snapshots) - 1
+	}
+	f.calls++
+
+	snapshot := f.snapshots[i]
+
+	return &snapshot, nil
+}
+
+func (f *fakeBookAPI) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func (f *fakeBookWebsocket) SubscribeBook(context.Context, string, int) (<-chan cdcexchange.BookResult, error) {
+	if f.subscribeErr != nil {
+		return nil, f.subscribeErr
+	}
+
+	return f.updates, nil
+}
+
+func (f *fakeBookWebsocket) UnsubscribeBook(context.Context, string, int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.unsubscribed = true
+
+	return nil
+}
+
+func (f *fakeBookWebsocket) Unsubscribed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.unsubscribed
+}
+
+func TestNewLocalOrderBook_Error(t *testing.T) {
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		api          cdcexchange.CommonAPI
+		ws           cdcexchange.MarketWebsocket
+		instrument   string
+		depth        int
+		expectedErr  error
+		unsubscribed bool
+	}{
+		{
+			name:        "returns error when api is nil",
+			ws:          &fakeBookWebsocket{},
+			instrument:  "some instrument",
+			depth:       10,
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "api", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when ws is nil",
+			api:         &fakeBookAPI{},
+			instrument:  "some instrument",
+			depth:       10,
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "ws", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when instrument is empty",
+			api:         &fakeBookAPI{},
+			ws:          &fakeBookWebsocket{},
+			depth:       10,
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when depth is not positive",
+			api:         &fakeBookAPI{},
+			ws:          &fakeBookWebsocket{},
+			instrument:  "some instrument",
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"},
+		},
+		{
+			name:        "returns error given error subscribing",
+			api:         &fakeBookAPI{},
+			ws:          &fakeBookWebsocket{subscribeErr: testErr},
+			instrument:  "some instrument",
+			depth:       10,
+			expectedErr: testErr,
+		},
+		{
+			name:         "returns error given error getting snapshot",
+			api:          &fakeBookAPI{err: testErr},
+			ws:           &fakeBookWebsocket{},
+			instrument:   "some instrument",
+			depth:        10,
+			expectedErr:  testErr,
+			unsubscribed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := cdcexchange.NewLocalOrderBook(context.Background(), tt.api, tt.ws, tt.instrument, tt.depth)
+			require.Error(t, err)
+
+			assert.Nil(t, book)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			if ws, ok := tt.ws.(*fakeBookWebsocket); ok {
+				assert.Equal(t, tt.unsubscribed, ws.Unsubscribed())
+			}
+		})
+	}
+}
+
+func TestLocalOrderBook_Queries(t *testing.T) {
+	now := time.Now().Round(time.Second)
+
+	book, _, _ := newLocalOrderBook(t, cdcexchange.BookResult{
+		Bids:      [][]decimal.Decimal{{d("99"), d("2"), d("1")}, {d("100"), d("1"), d("2")}, {d("98"), d("3"), d("1")}},
+		Asks:      [][]decimal.Decimal{{d("102"), d("4"), d("1")}, {d("101"), d("5"), d("3")}},
+		Timestamp: cdctime.Time(now),
+		UpdateID:  10,
+	})
+
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("100"), Quantity: d("1"), NumberOfOrders: 2},
+		{Price: d("99"), Quantity: d("2"), NumberOfOrders: 1},
+		{Price: d("98"), Quantity: d("3"), NumberOfOrders: 1},
+	}, book.Bids())
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("101"), Quantity: d("5"), NumberOfOrders: 3},
+		{Price: d("102"), Quantity: d("4"), NumberOfOrders: 1},
+	}, book.Asks())
+
+	bid, ok := book.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, d("100"), bid.Price)
+
+	ask, ok := book.BestAsk()
+	require.True(t, ok)
+	assert.Equal(t, d("101"), ask.Price)
+
+	spread, ok := book.Spread()
+	require.True(t, ok)
+	assert.Equal(t, d("1"), spread)
+
+	mid, ok := book.Mid()
+	require.True(t, ok)
+	assert.Equal(t, d("100.5"), mid)
+
+	assert.Equal(t, d("2"), book.DepthAtPrice(cdcexchange.OrderSideBuy, d("99")))
+	assert.Equal(t, d("4"), book.DepthAtPrice(cdcexchange.OrderSideSell, d("102")))
+	assert.Equal(t, d("0"), book.DepthAtPrice(cdcexchange.OrderSideSell, d("99")))
+
+	assert.Equal(t, d("3"), book.CumulativeVolume(cdcexchange.OrderSideBuy, d("99")))
+	assert.Equal(t, d("6"), book.CumulativeVolume(cdcexchange.OrderSideBuy, d("0")))
+	assert.Equal(t, d("5"), book.CumulativeVolume(cdcexchange.OrderSideSell, d("101.5")))
+	assert.Equal(t, d("0"), book.CumulativeVolume(cdcexchange.OrderSideSell, d("100")))
+
+	assert.Equal(t, int64(10), book.UpdateID())
+	assert.Equal(t, now, book.Timestamp())
+	assert.True(t, book.InSync())
+}
+
+func TestLocalOrderBook_EmptySide(t *testing.T) {
+	book, _, _ := newLocalOrderBook(t, cdcexchange.BookResult{
+		Bids: [][]decimal.Decimal{{d("100"), d("1"), d("1")}},
+	})
+
+	_, ok := book.BestAsk()
+	assert.False(t, ok)
+
+	_, ok = book.Spread()
+	assert.False(t, ok)
+
+	_, ok = book.Mid()
+	assert.False(t, ok)
+}
+
+func TestLocalOrderBook_Updates(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       cdcexchange.BookResult
+		expectedBids []cdcexchange.BookLevel
+		expectedAsks []cdcexchange.BookLevel
+		expectedID   int64
+	}{
+		{
+			name: "applies incremental update",
+			update: cdcexchange.BookResult{
+				Bids:             [][]decimal.Decimal{{d("100"), d("0"), d("0")}, {d("99.5"), d("7"), d("2")}},
+				Asks:             [][]decimal.Decimal{{d("101"), d("6"), d("4")}},
+				UpdateID:         11,
+				PreviousUpdateID: 10,
+			},
+			expectedBids: []cdcexchange.BookLevel{
+				{Price: d("99.5"), Quantity: d("7"), NumberOfOrders: 2},
+				{Price: d("99"), Quantity: d("2"), NumberOfOrders: 1},
+			},
+			expectedAsks: []cdcexchange.BookLevel{
+				{Price: d("101"), Quantity: d("6"), NumberOfOrders: 4},
+			},
+			expectedID: 11,
+		},
+		{
+			name: "replaces book given snapshot",
+			update: cdcexchange.BookResult{
+				Bids:     [][]decimal.Decimal{{d("95"), d("1"), d("1")}},
+				Asks:     [][]decimal.Decimal{{d("96"), d("1"), d("1")}},
+				UpdateID: 20,
+			},
+			expectedBids: []cdcexchange.BookLevel{{Price: d("95"), Quantity: d("1"), NumberOfOrders: 1}},
+			expectedAsks: []cdcexchange.BookLevel{{Price: d("96"), Quantity: d("1"), NumberOfOrders: 1}},
+			expectedID:   20,
+		},
+		{
+			name: "discards update which precedes the book",
+			update: cdcexchange.BookResult{
+				Bids:             [][]decimal.Decimal{{d("100"), d("0"), d("0")}},
+				UpdateID:         10,
+				PreviousUpdateID: 9,
+			},
+			expectedBids: []cdcexchange.BookLevel{
+				{Price: d("100"), Quantity: d("1"), NumberOfOrders: 1},
+				{Price: d("99"), Quantity: d("2"), NumberOfOrders: 1},
+			},
+			expectedAsks: []cdcexchange.BookLevel{
+				{Price: d("101"), Quantity: d("5"), NumberOfOrders: 1},
+			},
+			expectedID: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, _, ws := newLocalOrderBook(t, cdcexchange.BookResult{
+				Bids:     [][]decimal.Decimal{{d("100"), d("1"), d("1")}, {d("99"), d("2"), d("1")}},
+				Asks:     [][]decimal.Decimal{{d("101"), d("5"), d("1")}},
+				UpdateID: 10,
+			})
+
+			ws.updates <- tt.update
+			// the updates are applied in order, so once the marker is applied so is the update.
+			ws.updates <- cdcexchange.BookResult{UpdateID: tt.expectedID, PreviousUpdateID: tt.expectedID - 1}
+
+			assert.Eventually(t, func() bool {
+				return book.UpdateID() == tt.expectedID
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, tt.expectedBids, book.Bids())
+			assert.Equal(t, tt.expectedAsks, book.Asks())
+		})
+	}
+}
+
+func TestLocalOrderBook_ResyncsGivenSequenceGap(t *testing.T) {
+	book, api, ws := newLocalOrderBook(t,
+		cdcexchange.BookResult{
+			Bids:     [][]decimal.Decimal{{d("100"), d("1"), d("1")}},
+			UpdateID: 10,
+		},
+		cdcexchange.BookResult{
+			Bids:     [][]decimal.Decimal{{d("90"), d("1"), d("1")}},
+			Asks:     [][]decimal.Decimal{{d("91"), d("1"), d("1")}},
+			UpdateID: 15,
+		},
+	)
+
+	// update 11 is missed.
+	ws.updates <- cdcexchange.BookResult{
+		Bids:             [][]decimal.Decimal{{d("100"), d("0"), d("0")}},
+		UpdateID:         12,
+		PreviousUpdateID: 11,
+	}
+
+	assert.Eventually(t, func() bool {
+		return book.UpdateID() == 15
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, api.Calls())
+	assert.True(t, book.InSync())
+	assert.Equal(t, []cdcexchange.BookLevel{{Price: d("90"), Quantity: d("1"), NumberOfOrders: 1}}, book.Bids())
+	assert.Equal(t, []cdcexchange.BookLevel{{Price: d("91"), Quantity: d("1"), NumberOfOrders: 1}}, book.Asks())
+
+	// updates following on from the resynced book are applied.
+	ws.updates <- cdcexchange.BookResult{
+		Asks:             [][]decimal.Decimal{{d("91"), d("3"), d("2")}},
+		UpdateID:         16,
+		PreviousUpdateID: 15,
+	}
+
+	assert.Eventually(t, func() bool {
+		return book.DepthAtPrice(cdcexchange.OrderSideSell, d("91")) == d("3")
+	}, time.Second, time.Millisecond)
+}
+
+func TestLocalOrderBook_AppliesUpdateAfterResync(t *testing.T) {
+	book, api, ws := newLocalOrderBook(t,
+		cdcexchange.BookResult{
+			Bids:     [][]decimal.Decimal{{d("100"), d("1"), d("1")}},
+			UpdateID: 10,
+		},
+		cdcexchange.BookResult{
+			Bids:     [][]decimal.Decimal{{d("100"), d("1"), d("1")}},
+			UpdateID: 11,
+		},
+	)
+
+	// update 11 is missed, but is included in the resynced book, so update 12 follows on from it.
+	ws.updates <- cdcexchange.BookResult{
+		Bids:             [][]decimal.Decimal{{d("100"), d("4"), d("2")}},
+		UpdateID:         12,
+		PreviousUpdateID: 11,
+	}
+
+	assert.Eventually(t, func() bool {
+		return book.UpdateID() == 12
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, api.Calls())
+	assert.True(t, book.InSync())
+	assert.Equal(t, []cdcexchange.BookLevel{{Price: d("100"), Quantity: d("4"), NumberOfOrders: 2}}, book.Bids())
+}
+
+func TestLocalOrderBook_SnapshotWithoutUpdateID(t *testing.T) {
+	book, api, ws := newLocalOrderBook(t, cdcexchange.BookResult{
+		Bids: [][]decimal.Decimal{{d("100"), d("1"), d("1")}},
+	})
+
+	// the first update sets the baseline, as the snapshot has no update ID to check it against.
+	ws.updates <- cdcexchange.BookResult{
+		Bids:             [][]decimal.Decimal{{d("99"), d("2"), d("1")}},
+		UpdateID:         21,
+		PreviousUpdateID: 20,
+	}
+	ws.updates <- cdcexchange.BookResult{
+		Bids:             [][]decimal.Decimal{{d("100"), d("0"), d("0")}},
+		UpdateID:         22,
+		PreviousUpdateID: 21,
+	}
+
+	assert.Eventually(t, func() bool {
+		return book.UpdateID() == 22
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, api.Calls())
+	assert.Equal(t, []cdcexchange.BookLevel{{Price: d("99"), Quantity: d("2"), NumberOfOrders: 1}}, book.Bids())
+
+	// updates which do not follow on from the baseline cause a resync.
+	ws.updates <- cdcexchange.BookResult{UpdateID: 24, PreviousUpdateID: 23}
+
+	assert.Eventually(t, func() bool {
+		return api.Calls() == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestLocalOrderBook_TruncatesToDepth(t *testing.T) {
+	var (
+		api = &fakeBookAPI{snapshots: []cdcexchange.BookResult{{
+			Bids:     [][]decimal.Decimal{{d("100"), d("1"), d("1")}, {d("99"), d("2"), d("1")}, {d("98"), d("3"), d("1")}},
+			Asks:     [][]decimal.Decimal{{d("101"), d("1"), d("1")}, {d("102"), d("2"), d("1")}, {d("103"), d("3"), d("1")}},
+			UpdateID: 10,
+		}}}
+		ws = &fakeBookWebsocket{updates: make(chan cdcexchange.BookResult)}
+	)
+
+	book, err := cdcexchange.NewLocalOrderBook(context.Background(), api, ws, "some instrument", 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("100"), Quantity: d("1"), NumberOfOrders: 1},
+		{Price: d("99"), Quantity: d("2"), NumberOfOrders: 1},
+	}, book.Bids())
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("101"), Quantity: d("1"), NumberOfOrders: 1},
+		{Price: d("102"), Quantity: d("2"), NumberOfOrders: 1},
+	}, book.Asks())
+
+	ws.updates <- cdcexchange.BookResult{
+		Bids:             [][]decimal.Decimal{{d("100.5"), d("4"), d("1")}},
+		Asks:             [][]decimal.Decimal{{d("100.75"), d("4"), d("1")}},
+		UpdateID:         11,
+		PreviousUpdateID: 10,
+	}
+
+	assert.Eventually(t, func() bool {
+		return book.UpdateID() == 11
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("100.5"), Quantity: d("4"), NumberOfOrders: 1},
+		{Price: d("100"), Quantity: d("1"), NumberOfOrders: 1},
+	}, book.Bids())
+	assert.Equal(t, []cdcexchange.BookLevel{
+		{Price: d("100.75"), Quantity: d("4"), NumberOfOrders: 1},
+		{Price: d("101"), Quantity: d("1"), NumberOfOrders: 1},
+	}, book.Asks())
+}
+
+func TestLocalOrderBook_Close(t *testing.T) {
+	book, _, ws := newLocalOrderBook(t, cdcexchange.BookResult{})
+
+	require.NoError(t, book.Close())
+
+	assert.True(t, ws.Unsubscribed())
+	assertClosed(t, book.Done())
+}
+
+func TestLocalOrderBook_StopsWhenSubscriptionClosed(t *testing.T) {
+	book, _, ws := newLocalOrderBook(t, cdcexchange.BookResult{})
+
+	close(ws.updates)
+
+	assertClosed(t, book.Done())
+}
+
+func newLocalOrderBook(t *testing.T, snapshots ...cdcexchange.BookResult) (*cdcexchange.LocalOrderBook, *fakeBookAPI, *fakeBookWebsocket) {
+	var (
+		api = &fakeBookAPI{snapshots: snapshots}
+		ws  = &fakeBookWebsocket{updates: make(chan cdcexchange.BookResult)}
+	)
+
+	book, err := cdcexchange.NewLocalOrderBook(context.Background(), api, ws, "some instrument", 10)
+	require.NoError(t, err)
+
+	return book, api, ws
+}
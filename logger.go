@@ -0,0 +1,70 @@
+package cdcexchange
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+// WithLogger enables structured logging of the REST requests made by the client (Default: nothing is logged).
+//
+// Each request is logged with its method, id, nonce & params, along with the HTTP status code, response code &
+// latency of the response. Successful requests are logged at debug level, error responses at warn level &
+// requests which could not be sent at error level. The API key, signature & secret key are never logged.
+//
+// Requests are logged after any interceptors, as they are sent to the Exchange, so each attempt of a request is
+// logged and results returned by an interceptor without invoking the request are not.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *client) error {
+		if logger == nil {
+			return errors.InvalidParameterError{Parameter: "logger", Reason: "cannot be empty"}
+		}
+
+		c.logger = logger
+		return nil
+	}
+}
+
+// newInterceptors creates the chain of interceptors from the client's configuration,
+// the logger is the innermost so that it logs the requests as they are sent.
+func (c *client) newInterceptors() []Interceptor {
+	if c.logger == nil {
+		return c.interceptors
+	}
+
+	interceptors := make([]Interceptor, 0, len(c.interceptors)+1)
+	interceptors = append(interceptors, c.interceptors...)
+
+	return append(interceptors, logRequests(c.logger))
+}
+
+// logRequests returns an interceptor which logs each request & its result.
+func logRequests(logger *slog.Logger) Interceptor {
+	return func(ctx context.Context, call Call, invoke Invoker) (CallResult, error) {
+		result, err := invoke(ctx, call)
+
+		// call.Request implements slog.LogValuer, which redacts the API key & signature.
+		attrs := []slog.Attr{
+			slog.Any("request", call.Request),
+			slog.Duration("latency", result.Latency),
+		}
+
+		level, msg := slog.LevelDebug, "request completed"
+		switch {
+		case err != nil:
+			level, msg = slog.LevelError, "request failed"
+			attrs = append(attrs, slog.Any("error", err))
+		case result.StatusCode >= http.StatusBadRequest:
+			level, msg = slog.LevelWarn, "request returned error response"
+		}
+		if err == nil {
+			attrs = append(attrs, slog.Int("status", result.StatusCode), slog.String("code", result.Code.String()))
+		}
+
+		logger.LogAttrs(ctx, level, msg, attrs...)
+
+		return result, err
+	}
+}
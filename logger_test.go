@@ -0,0 +1,130 @@
+package cdcexchange_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+func TestClient_Logger(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		latency   = 250 * time.Millisecond
+	)
+
+	tests := []struct {
+		name           string
+		code           int
+		expectedStatus int
+		expectedLevel  string
+		expectedMsg    string
+		expectedErr    error
+	}{
+		{
+			name:           "logs successful request at debug level",
+			expectedStatus: http.StatusOK,
+			expectedLevel:  "DEBUG",
+			expectedMsg:    "request completed",
+		},
+		{
+			name:           "logs error response at warn level",
+			code:           10003,
+			expectedStatus: http.StatusBadRequest,
+			expectedLevel:  "WARN",
+			expectedMsg:    "request returned error response",
+			expectedErr:    cdcerrors.ErrIllegalIP,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.expectedStatus)
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":1,"method":"","code":%d,"result":{"data":[]}}`, tt.code)))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			var (
+				clock     = clockwork.NewFakeClock()
+				logs      bytes.Buffer
+				signature string
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&http.Client{
+					Transport: slowTransport{clock: clock, latency: latency, base: s.Client().Transport},
+				}),
+				cdcexchange.WithBaseURL(s.URL),
+				cdcexchange.WithRateLimitDisabled(),
+				cdcexchange.WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+				cdcexchange.WithInterceptors(func(ctx context.Context, call cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+					signature = call.Request.Signature
+					return invoke(ctx, call)
+				}),
+			)
+			require.NoError(t, err)
+
+			_, err = client.GetOpenOrders(context.Background(), cdcexchange.GetOpenOrdersRequest{InstrumentName: "CRO_USDT"})
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+
+			require.NotEmpty(t, signature)
+			assert.NotContains(t, logs.String(), apiKey)
+			assert.NotContains(t, logs.String(), secretKey)
+			assert.NotContains(t, logs.String(), signature)
+
+			lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+			require.Len(t, lines, 1)
+
+			var entry struct {
+				Level   string `json:"level"`
+				Msg     string `json:"msg"`
+				Request struct {
+					ID     int64                  `json:"id"`
+					Method string                 `json:"method"`
+					Nonce  int64                  `json:"nonce"`
+					Params map[string]interface{} `json:"params"`
+					APIKey string                 `json:"api_key"`
+					Sig    string                 `json:"sig"`
+				} `json:"request"`
+				Latency time.Duration `json:"latency"`
+				Status  int           `json:"status"`
+				Code    string        `json:"code"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+			assert.Equal(t, tt.expectedLevel, entry.Level)
+			assert.Equal(t, tt.expectedMsg, entry.Msg)
+			assert.NotZero(t, entry.Request.ID)
+			assert.Equal(t, cdcexchange.MethodGetOpenOrders, entry.Request.Method)
+			assert.Equal(t, clock.Now().Add(-latency).UnixMilli(), entry.Request.Nonce)
+			assert.Equal(t, "CRO_USDT", entry.Request.Params["instrument_name"])
+			assert.Equal(t, "REDACTED", entry.Request.APIKey)
+			assert.Equal(t, "REDACTED", entry.Request.Sig)
+			assert.Equal(t, latency, entry.Latency)
+			assert.Equal(t, tt.expectedStatus, entry.Status)
+			assert.Equal(t, fmt.Sprint(tt.code), entry.Code)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const methodMarginBorrow = "private/margin/borrow"
+
+// MarginBorrowResponse is the base response returned from the private/margin/borrow API.
+type MarginBorrowResponse struct {
+	// api.BaseResponse is the common response fields.
+	api.BaseResponse
+}
+
+// MarginBorrow borrows an amount of a particular currency into the margin wallet.
+//
+// Method: private/margin/borrow
+func (c *client) MarginBorrow(ctx context.Context, currency string, amount decimal.Decimal) error {
+	if currency == "" {
+		return errors.InvalidParameterError{Parameter: "currency", Reason: "cannot be empty"}
+	}
+	if amount.Sign() <= 0 {
+		return errors.InvalidParameterError{Parameter: "amount", Reason: "must be greater than 0"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = currency
+	params["amount"] = amount
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodMarginBorrow,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodMarginBorrow,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var borrowResponse MarginBorrowResponse
+	statusCode, err := c.requester.WithoutRetries().Post(ctx, body, methodMarginBorrow, &borrowResponse)
+	if err != nil {
+		return fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, borrowResponse.Code); err != nil {
+		return fmt.Errorf("error received in response: %w", err)
+	}
+
+	return nil
+}
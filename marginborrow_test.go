@@ -0,0 +1,244 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_MarginBorrow_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "BTC"
+	)
+	var (
+		amount  = decimal.MustParse("1.5")
+		testErr = errors.New("some error")
+	)
+
+	type args struct {
+		currency string
+		amount   decimal.Decimal
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			args: args{
+				amount: amount,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when amount is 0",
+			args: args{
+				currency: currency,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "returns error when amount is negative",
+			args: args{
+				currency: currency,
+				amount:   decimal.MustParse("-1"),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "returns error given error generating signature",
+			args: args{
+				currency: currency,
+				amount:   amount,
+			},
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: args{
+				currency: currency,
+				amount:   amount,
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: args{
+				currency: currency,
+				amount:   amount,
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			if tt.currency != "" && tt.amount.Sign() > 0 {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodMarginBorrow,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": currency,
+						"amount":   amount,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			err = client.MarginBorrow(ctx, tt.currency, tt.amount)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_MarginBorrow_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+		currency  = "BTC"
+	)
+	var (
+		now    = time.Now()
+		amount = decimal.MustParse("1.5")
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodMarginBorrow)
+		assert.Equal(t, http.MethodPost, r.Method)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodMarginBorrow, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, currency, body.Params["currency"])
+		assert.Equal(t, amount.Float64(), body.Params["amount"])
+
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.MarginBorrowResponse{}))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodMarginBorrow,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"currency": currency,
+			"amount":   amount,
+		},
+	}).Return(signature, nil)
+
+	require.NoError(t, client.MarginBorrow(ctx, currency, amount))
+}
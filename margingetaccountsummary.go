@@ -0,0 +1,132 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginAccountSummary = "private/margin/get-account-summary"
+)
+
+type (
+	// MarginAccountSummaryResponse is the base response returned from the private/margin/get-account-summary API.
+	MarginAccountSummaryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result MarginAccountSummary `json:"result"`
+	}
+
+	// MarginAccountSummary is the summary of the user's margin account.
+	MarginAccountSummary struct {
+		// Accounts is the returned account data for each currency.
+		Accounts []MarginAccount `json:"accounts"`
+		// IsLiquidating is true if the margin account is being liquidated.
+		IsLiquidating bool `json:"is_liquidating"`
+		// TotalBalance is the total balance of the account, in the account currency.
+		TotalBalance decimal.Decimal `json:"total_balance"`
+		// TotalBalanceBTC is the total balance of the account, in BTC.
+		TotalBalanceBTC decimal.Decimal `json:"total_balance_btc"`
+		// EquityValue is the equity value of the account (total balance less borrowed amounts), in the account currency.
+		EquityValue decimal.Decimal `json:"equity_value"`
+		// EquityValueBTC is the equity value of the account, in BTC.
+		EquityValueBTC decimal.Decimal `json:"equity_value_btc"`
+		// TotalBorrowed is the total amount borrowed, in the account currency.
+		TotalBorrowed decimal.Decimal `json:"total_borrowed"`
+		// TotalBorrowedBTC is the total amount borrowed, in BTC.
+		TotalBorrowedBTC decimal.Decimal `json:"total_borrowed_btc"`
+		// TotalAccruedInterest is the total interest accrued on borrowed amounts, in the account currency.
+		TotalAccruedInterest decimal.Decimal `json:"total_accrued_interest"`
+		// TotalAccruedInterestBTC is the total interest accrued on borrowed amounts, in BTC.
+		TotalAccruedInterestBTC decimal.Decimal `json:"total_accrued_interest_btc"`
+		// MarginScore is the margin score of the account (e.g. GOOD, CAUTION, DANGER).
+		MarginScore string `json:"margin_score"`
+		// Currency is the currency in which the totals are valued (e.g. USDT).
+		Currency string `json:"currency"`
+	}
+
+	// MarginAccount represents margin balance details of a specific token.
+	MarginAccount struct {
+		// Balance is the total balance (Available + Order).
+		Balance decimal.Decimal `json:"balance"`
+		// Available is the available balance (e.g. not in orders, or locked, etc.).
+		Available decimal.Decimal `json:"available"`
+		// Order is the balance locked in orders.
+		Order decimal.Decimal `json:"order"`
+		// Borrowed is the amount borrowed.
+		Borrowed decimal.Decimal `json:"borrowed"`
+		// Position is the net position (Balance - Borrowed).
+		Position decimal.Decimal `json:"position"`
+		// PositionHomeCurrency is the position valued in the account currency.
+		PositionHomeCurrency decimal.Decimal `json:"positionHomeCurrency"`
+		// PositionBTC is the position valued in BTC.
+		PositionBTC decimal.Decimal `json:"positionBtc"`
+		// LastPriceHomeCurrency is the last price of the token in the account currency.
+		LastPriceHomeCurrency decimal.Decimal `json:"lastPriceHomeCurrency"`
+		// LastPriceBTC is the last price of the token in BTC.
+		LastPriceBTC decimal.Decimal `json:"lastPriceBtc"`
+		// Currency is the symbol for the currency (e.g. CRO).
+		Currency string `json:"currency"`
+		// AccruedInterest is the interest accrued on the borrowed amount.
+		AccruedInterest decimal.Decimal `json:"accrued_interest"`
+		// LiquidationPrice is the price at which the position would be liquidated.
+		LiquidationPrice decimal.Decimal `json:"liquidation_price"`
+	}
+)
+
+// GetMarginAccountSummary returns the margin account balance of a user for a particular token.
+//
+// currency can be left blank to retrieve balances for ALL tokens.
+//
+// Method: private/margin/get-account-summary
+func (c *client) GetMarginAccountSummary(ctx context.Context, currency string) (*MarginAccountSummary, error) {
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	// if currency is omitted, ALL currencies are returned.
+	if currency != "" {
+		params["currency"] = currency
+	}
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginAccountSummary,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginAccountSummary,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var accountSummaryResponse MarginAccountSummaryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginAccountSummary, &accountSummaryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, accountSummaryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return &accountSummaryResponse.Result, nil
+}
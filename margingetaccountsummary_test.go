@@ -0,0 +1,277 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_GetMarginAccountSummary_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "some currency"
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetMarginAccountSummary,
+				Timestamp: now.UnixMilli(),
+				Params:    map[string]interface{}{"currency": currency},
+			}).Return("signature", tt.signatureErr)
+
+			summary, err := client.GetMarginAccountSummary(ctx, currency)
+			require.Error(t, err)
+
+			assert.Nil(t, summary)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetMarginAccountSummary_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "BTC"
+		signature = "some signature"
+	)
+	now := time.Now()
+
+	response := `{
+		"id": 0,
+		"method": "",
+		"code": 0,
+		"result": {
+			"accounts": [
+				{
+					"balance": 1.5,
+					"available": 1,
+					"order": 0.5,
+					"borrowed": 0.2,
+					"position": 1.3,
+					"positionHomeCurrency": 39000,
+					"positionBtc": 1.3,
+					"lastPriceHomeCurrency": 30000,
+					"lastPriceBtc": 1,
+					"currency": "BTC",
+					"accrued_interest": 0.0001,
+					"liquidation_price": 15000
+				}
+			],
+			"is_liquidating": false,
+			"total_balance": 45000,
+			"total_balance_btc": 1.5,
+			"equity_value": 39000,
+			"equity_value_btc": 1.3,
+			"total_borrowed": 6000,
+			"total_borrowed_btc": 0.2,
+			"total_accrued_interest": 3,
+			"total_accrued_interest_btc": 0.0001,
+			"margin_score": "GOOD",
+			"currency": "USDT"
+		}
+	}`
+
+	expectedResult := &cdcexchange.MarginAccountSummary{
+		Accounts: []cdcexchange.MarginAccount{
+			{
+				Balance:               decimal.MustParse("1.5"),
+				Available:             decimal.MustParse("1"),
+				Order:                 decimal.MustParse("0.5"),
+				Borrowed:              decimal.MustParse("0.2"),
+				Position:              decimal.MustParse("1.3"),
+				PositionHomeCurrency:  decimal.MustParse("39000"),
+				PositionBTC:           decimal.MustParse("1.3"),
+				LastPriceHomeCurrency: decimal.MustParse("30000"),
+				LastPriceBTC:          decimal.MustParse("1"),
+				Currency:              "BTC",
+				AccruedInterest:       decimal.MustParse("0.0001"),
+				LiquidationPrice:      decimal.MustParse("15000"),
+			},
+		},
+		TotalBalance:            decimal.MustParse("45000"),
+		TotalBalanceBTC:         decimal.MustParse("1.5"),
+		EquityValue:             decimal.MustParse("39000"),
+		EquityValueBTC:          decimal.MustParse("1.3"),
+		TotalBorrowed:           decimal.MustParse("6000"),
+		TotalBorrowedBTC:        decimal.MustParse("0.2"),
+		TotalAccruedInterest:    decimal.MustParse("3"),
+		TotalAccruedInterestBTC: decimal.MustParse("0.0001"),
+		MarginScore:             "GOOD",
+		Currency:                "USDT",
+	}
+
+	type args struct {
+		currency string
+	}
+	tests := []struct {
+		name string
+		args
+		expectedParams map[string]interface{}
+	}{
+		{
+			name: "returns margin account summary for all currencies",
+			args: args{
+				currency: "",
+			},
+			expectedParams: map[string]interface{}{},
+		},
+		{
+			name: "returns margin account summary for currency",
+			args: args{
+				currency: currency,
+			},
+			expectedParams: map[string]interface{}{"currency": currency},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetMarginAccountSummary)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodGetMarginAccountSummary, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Equal(t, tt.expectedParams, body.Params)
+
+				_, err := w.Write([]byte(response))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetMarginAccountSummary,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			summary, err := client.GetMarginAccountSummary(ctx, tt.currency)
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedResult, summary)
+		})
+	}
+}
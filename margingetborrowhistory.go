@@ -0,0 +1,150 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginBorrowHistory = "private/margin/get-borrow-history"
+)
+
+type (
+	// GetMarginBorrowHistoryRequest is the request params sent for the private/margin/get-borrow-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetMarginBorrowHistoryRequest struct {
+		// Currency is the currency of the loans (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of loans returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginBorrowHistoryResponse is the base response returned from the private/margin/get-borrow-history API.
+	GetMarginBorrowHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginBorrowHistoryResult `json:"result"`
+	}
+
+	// GetMarginBorrowHistoryResult is the result returned from the private/margin/get-borrow-history API.
+	GetMarginBorrowHistoryResult struct {
+		// BorrowList is the array of loans.
+		BorrowList []MarginBorrowRecord `json:"borrow_list"`
+		// HasMore reports whether there may be more loans after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further loans exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// MarginBorrowRecord represents the details of an amount borrowed on margin.
+	MarginBorrowRecord struct {
+		// LoanID is the unique identifier for the loan.
+		LoanID string `json:"loan_id"`
+		// Currency is the currency borrowed (e.g. BTC).
+		Currency string `json:"currency"`
+		// LoanAmount is the amount borrowed.
+		LoanAmount decimal.Decimal `json:"loan_amount"`
+		// BorrowTime is the time the amount was borrowed.
+		BorrowTime cdctime.Time `json:"borrow_time"`
+		// Status is the status of the loan (e.g. ACTIVE, REPAID).
+		Status string `json:"status"`
+	}
+)
+
+// GetMarginBorrowHistory gets the history of amounts borrowed on margin.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/margin/get-borrow-history
+func (c *client) GetMarginBorrowHistory(ctx context.Context, req GetMarginBorrowHistoryRequest) (*GetMarginBorrowHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginBorrowHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginBorrowHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var borrowHistoryResponse GetMarginBorrowHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginBorrowHistory, &borrowHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, borrowHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := borrowHistoryResponse.Result
+	if len(res.BorrowList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,152 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginInterestHistory = "private/margin/get-interest-history"
+)
+
+type (
+	// GetMarginInterestHistoryRequest is the request params sent for the private/margin/get-interest-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetMarginInterestHistoryRequest struct {
+		// Currency is the currency of the interest charges (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of interest charges returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginInterestHistoryResponse is the base response returned from the private/margin/get-interest-history API.
+	GetMarginInterestHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginInterestHistoryResult `json:"result"`
+	}
+
+	// GetMarginInterestHistoryResult is the result returned from the private/margin/get-interest-history API.
+	GetMarginInterestHistoryResult struct {
+		// InterestList is the array of interest charges.
+		InterestList []MarginInterestRecord `json:"list"`
+		// HasMore reports whether there may be more interest charges after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further interest charges exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// MarginInterestRecord represents the details of interest charged on a borrowed amount.
+	MarginInterestRecord struct {
+		// LoanID is the unique identifier for the loan the interest was charged on.
+		LoanID string `json:"loan_id"`
+		// Currency is the currency of the interest (e.g. BTC).
+		Currency string `json:"currency"`
+		// Interest is the amount of interest charged.
+		Interest decimal.Decimal `json:"interest"`
+		// Time is the time the interest was charged.
+		Time cdctime.Time `json:"time"`
+		// StakeAmount is the amount of CRO staked at the time the interest was charged.
+		StakeAmount decimal.Decimal `json:"stake_amount"`
+		// InterestRate is the daily interest rate applied.
+		InterestRate decimal.Decimal `json:"interest_rate"`
+	}
+)
+
+// GetMarginInterestHistory gets the history of interest charged on borrowed amounts.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/margin/get-interest-history
+func (c *client) GetMarginInterestHistory(ctx context.Context, req GetMarginInterestHistoryRequest) (*GetMarginInterestHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginInterestHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginInterestHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var interestHistoryResponse GetMarginInterestHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginInterestHistory, &interestHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, interestHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := interestHistoryResponse.Result
+	if len(res.InterestList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
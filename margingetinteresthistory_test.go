@@ -0,0 +1,304 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_GetMarginInterestHistory_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	type args struct {
+		req cdcexchange.GetMarginInterestHistoryRequest
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when page size is less than 0",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{
+					PageSize: -1,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.PageSize",
+				Reason:    "cannot be less than 0",
+			},
+		},
+		{
+			name: "returns error when page size is greater than 200",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{
+					PageSize: 201,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.PageSize",
+				Reason:    "cannot be greater than 200",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			if tt.req.PageSize >= 0 && tt.req.PageSize <= 200 {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetMarginInterestHistory,
+					Timestamp: now.UnixMilli(),
+					Params:    map[string]interface{}{"page": 0},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.GetMarginInterestHistory(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetMarginInterestHistory_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	response := fmt.Sprintf(`{
+		"id": 0,
+		"method": "",
+		"code": 0,
+		"result": {
+			"list": [
+				{
+					"loan_id": "some loan id",
+					"currency": "BTC",
+					"interest": 0.0001,
+					"time": %d,
+					"stake_amount": 5000,
+					"interest_rate": 0.00075
+				}
+			]
+		}
+	}`, now.UnixMilli())
+
+	expectedResult := []cdcexchange.MarginInterestRecord{
+		{
+			LoanID:       "some loan id",
+			Currency:     "BTC",
+			Interest:     decimal.MustParse("0.0001"),
+			Time:         cdctime.Time(now),
+			StakeAmount:  decimal.MustParse("5000"),
+			InterestRate: decimal.MustParse("0.00075"),
+		},
+	}
+
+	type args struct {
+		req cdcexchange.GetMarginInterestHistoryRequest
+	}
+	tests := []struct {
+		name string
+		args
+		expectedParams   map[string]interface{}
+		expectedNextPage int
+	}{
+		{
+			name: "successfully gets interest history with default params",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{},
+			},
+			expectedParams: map[string]interface{}{
+				"page": 0,
+			},
+		},
+		{
+			name: "successfully gets interest history with all params",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{
+					Currency: "BTC",
+					Start:    now,
+					End:      now.Add(time.Hour),
+					PageSize: 100,
+					Page:     1,
+				},
+			},
+			expectedParams: map[string]interface{}{
+				"currency":  "BTC",
+				"start_ts":  now.UnixMilli(),
+				"end_ts":    now.Add(time.Hour).UnixMilli(),
+				"page_size": 100,
+				"page":      1,
+			},
+		},
+		{
+			name: "reports more results given a full page",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{
+					PageSize: 1,
+					Page:     2,
+				},
+			},
+			expectedParams: map[string]interface{}{
+				"page_size": 1,
+				"page":      2,
+			},
+			expectedNextPage: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetMarginInterestHistory)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodGetMarginInterestHistory, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Len(t, body.Params, len(tt.expectedParams))
+
+				_, err := w.Write([]byte(response))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetMarginInterestHistory,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			res, err := client.GetMarginInterestHistory(ctx, tt.req)
+			require.NoError(t, err)
+
+			expected := &cdcexchange.GetMarginInterestHistoryResult{InterestList: expectedResult}
+			if tt.expectedNextPage != 0 {
+				expected.HasMore, expected.NextPage = true, tt.expectedNextPage
+			}
+			assert.Equal(t, expected, res)
+		})
+	}
+}
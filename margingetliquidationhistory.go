@@ -0,0 +1,142 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginLiquidationHistory = "private/margin/get-liquidation-history"
+)
+
+type (
+	// GetMarginLiquidationHistoryRequest is the request params sent for the private/margin/get-liquidation-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetMarginLiquidationHistoryRequest struct {
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of liquidations returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginLiquidationHistoryResponse is the base response returned from the private/margin/get-liquidation-history API.
+	GetMarginLiquidationHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginLiquidationHistoryResult `json:"result"`
+	}
+
+	// GetMarginLiquidationHistoryResult is the result returned from the private/margin/get-liquidation-history API.
+	GetMarginLiquidationHistoryResult struct {
+		// LiquidationList is the array of liquidations.
+		LiquidationList []MarginLiquidationRecord `json:"list"`
+		// HasMore reports whether there may be more liquidations after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further liquidations exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// MarginLiquidationRecord represents the details of a liquidation of the margin account.
+	MarginLiquidationRecord struct {
+		// Time is the time of the liquidation.
+		Time cdctime.Time `json:"time"`
+		// TotalBorrowed is the total amount borrowed at the time of the liquidation, in USDT.
+		TotalBorrowed decimal.Decimal `json:"total_borrowed"`
+		// TotalBalance is the total balance at the time of the liquidation, in USDT.
+		TotalBalance decimal.Decimal `json:"total_balance"`
+		// Status is the status of the liquidation (e.g. COMPLETED).
+		Status string `json:"status"`
+	}
+)
+
+// GetMarginLiquidationHistory gets the history of liquidations of the margin account.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/margin/get-liquidation-history
+func (c *client) GetMarginLiquidationHistory(ctx context.Context, req GetMarginLiquidationHistoryRequest) (*GetMarginLiquidationHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginLiquidationHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginLiquidationHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var liquidationHistoryResponse GetMarginLiquidationHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginLiquidationHistory, &liquidationHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, liquidationHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := liquidationHistoryResponse.Result
+	if len(res.LiquidationList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,133 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+)
+
+const (
+	methodGetMarginLiquidationOrders = "private/margin/get-liquidation-orders"
+)
+
+type (
+	// GetMarginLiquidationOrdersRequest is the request params sent for the private/margin/get-liquidation-orders API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetMarginLiquidationOrdersRequest struct {
+		// InstrumentName represents the currency pair for the orders (e.g. ETH_CRO or BTC_USDT).
+		// if InstrumentName is omitted, all instruments will be returned.
+		InstrumentName string `json:"instrument_name"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of orders returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginLiquidationOrdersResponse is the base response returned from the private/margin/get-liquidation-orders API.
+	GetMarginLiquidationOrdersResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginLiquidationOrdersResult `json:"result"`
+	}
+
+	// GetMarginLiquidationOrdersResult is the result returned from the private/margin/get-liquidation-orders API.
+	GetMarginLiquidationOrdersResult struct {
+		// OrderList is the array of orders.
+		OrderList []Order `json:"order_list"`
+		// HasMore reports whether there may be more orders after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further orders exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+)
+
+// GetMarginLiquidationOrders gets the orders placed to liquidate the margin account.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/margin/get-liquidation-orders
+func (c *client) GetMarginLiquidationOrders(ctx context.Context, req GetMarginLiquidationOrdersRequest) (*GetMarginLiquidationOrdersResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.InstrumentName != "" {
+		params["instrument_name"] = req.InstrumentName
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginLiquidationOrders,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginLiquidationOrders,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var liquidationOrdersResponse GetMarginLiquidationOrdersResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginLiquidationOrders, &liquidationOrdersResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, liquidationOrdersResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := liquidationOrdersResponse.Result
+	if len(res.OrderList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
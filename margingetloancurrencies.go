@@ -0,0 +1,43 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+const (
+	methodGetMarginLoanCurrencies = "public/margin/get-loan-currencies"
+)
+
+// MarginLoanCurrenciesResponse is the base response returned from the public/margin/get-loan-currencies API.
+type MarginLoanCurrenciesResponse struct {
+	// api.BaseResponse is the common response fields.
+	api.BaseResponse
+	// Result is the list of currencies which can be borrowed.
+	Result []string `json:"result"`
+}
+
+// GetMarginLoanCurrencies fetches the currencies which can be borrowed on margin.
+//
+// Method: public/margin/get-loan-currencies
+func (c *client) GetMarginLoanCurrencies(ctx context.Context) ([]string, error) {
+	body := api.Request{
+		ID:     c.idGenerator.Generate(),
+		Method: methodGetMarginLoanCurrencies,
+		Nonce:  c.nonce(),
+	}
+
+	var loanCurrenciesResponse MarginLoanCurrenciesResponse
+	statusCode, err := c.requester.Get(ctx, body, methodGetMarginLoanCurrencies, &loanCurrenciesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, loanCurrenciesResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return loanCurrenciesResponse.Result, nil
+}
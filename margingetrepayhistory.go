@@ -0,0 +1,152 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginRepayHistory = "private/margin/get-repay-history"
+)
+
+type (
+	// GetMarginRepayHistoryRequest is the request params sent for the private/margin/get-repay-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetMarginRepayHistoryRequest struct {
+		// Currency is the currency of the repayments (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of repayments returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginRepayHistoryResponse is the base response returned from the private/margin/get-repay-history API.
+	GetMarginRepayHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginRepayHistoryResult `json:"result"`
+	}
+
+	// GetMarginRepayHistoryResult is the result returned from the private/margin/get-repay-history API.
+	GetMarginRepayHistoryResult struct {
+		// RepayList is the array of repayments.
+		RepayList []MarginRepayRecord `json:"repay_list"`
+		// HasMore reports whether there may be more repayments after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further repayments exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// MarginRepayRecord represents the details of a repayment of a borrowed amount.
+	MarginRepayRecord struct {
+		// Currency is the currency repaid (e.g. BTC).
+		Currency string `json:"currency"`
+		// RepayAmount is the total amount repaid (Principal + Interest).
+		RepayAmount decimal.Decimal `json:"repay_amount"`
+		// Principal is the amount of the borrowed amount repaid.
+		Principal decimal.Decimal `json:"principal"`
+		// Interest is the amount of accrued interest repaid.
+		Interest decimal.Decimal `json:"interest"`
+		// RepayTime is the time of the repayment.
+		RepayTime cdctime.Time `json:"repay_time"`
+		// Status is the status of the repayment (e.g. COMPLETED).
+		Status string `json:"status"`
+	}
+)
+
+// GetMarginRepayHistory gets the history of repayments of borrowed amounts.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/margin/get-repay-history
+func (c *client) GetMarginRepayHistory(ctx context.Context, req GetMarginRepayHistoryRequest) (*GetMarginRepayHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginRepayHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginRepayHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var repayHistoryResponse GetMarginRepayHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginRepayHistory, &repayHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, repayHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := repayHistoryResponse.Result
+	if len(res.RepayList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
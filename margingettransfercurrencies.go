@@ -0,0 +1,43 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+const (
+	methodGetMarginTransferCurrencies = "public/margin/get-transfer-currencies"
+)
+
+// MarginTransferCurrenciesResponse is the base response returned from the public/margin/get-transfer-currencies API.
+type MarginTransferCurrenciesResponse struct {
+	// api.BaseResponse is the common response fields.
+	api.BaseResponse
+	// Result is the list of currencies which can be transferred to the margin wallet.
+	Result []string `json:"result"`
+}
+
+// GetMarginTransferCurrencies fetches the currencies which can be transferred to the margin wallet.
+//
+// Method: public/margin/get-transfer-currencies
+func (c *client) GetMarginTransferCurrencies(ctx context.Context) ([]string, error) {
+	body := api.Request{
+		ID:     c.idGenerator.Generate(),
+		Method: methodGetMarginTransferCurrencies,
+		Nonce:  c.nonce(),
+	}
+
+	var transferCurrenciesResponse MarginTransferCurrenciesResponse
+	statusCode, err := c.requester.Get(ctx, body, methodGetMarginTransferCurrencies, &transferCurrenciesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, transferCurrenciesResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return transferCurrenciesResponse.Result, nil
+}
@@ -0,0 +1,87 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetMarginUserConfig = "private/margin/get-user-config"
+)
+
+type (
+	// MarginUserConfigResponse is the base response returned from the private/margin/get-user-config API.
+	MarginUserConfigResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result MarginUserConfig `json:"result"`
+	}
+
+	// MarginUserConfig is the margin trading configuration of the user.
+	MarginUserConfig struct {
+		// StakeAmount is the amount of CRO staked, which determines the interest rates applied.
+		StakeAmount decimal.Decimal `json:"stake_amount"`
+		// CurrencyConfigs is the borrowing configuration of each currency, keyed by currency (e.g. BTC).
+		CurrencyConfigs map[string]MarginCurrencyConfig `json:"currency_configs"`
+	}
+
+	// MarginCurrencyConfig is the borrowing configuration of a specific currency.
+	MarginCurrencyConfig struct {
+		// DailyInterestRate is the daily interest rate charged on borrowed amounts.
+		DailyInterestRate decimal.Decimal `json:"daily_interest_rate"`
+		// MaxBorrowLimit is the maximum amount which can be borrowed.
+		MaxBorrowLimit decimal.Decimal `json:"max_borrow_limit"`
+		// MinBorrowLimit is the minimum amount which can be borrowed.
+		MinBorrowLimit decimal.Decimal `json:"min_borrow_limit"`
+	}
+)
+
+// GetMarginUserConfig fetches the margin trading configuration of the user.
+//
+// Method: private/margin/get-user-config
+func (c *client) GetMarginUserConfig(ctx context.Context) (*MarginUserConfig, error) {
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetMarginUserConfig,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetMarginUserConfig,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var userConfigResponse MarginUserConfigResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetMarginUserConfig, &userConfigResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, userConfigResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return &userConfigResponse.Result, nil
+}
@@ -0,0 +1,206 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_GetMarginUserConfig_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetMarginUserConfig,
+				Timestamp: now.UnixMilli(),
+				Params:    map[string]interface{}{},
+			}).Return("signature", tt.signatureErr)
+
+			config, err := client.GetMarginUserConfig(ctx)
+			require.Error(t, err)
+
+			assert.Nil(t, config)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetMarginUserConfig_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now()
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetMarginUserConfig)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetMarginUserConfig, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+
+		_, err := w.Write([]byte(`{
+			"id": 0,
+			"method": "",
+			"code": 0,
+			"result": {
+				"stake_amount": 5000,
+				"currency_configs": {
+					"BTC": {
+						"daily_interest_rate": 0.00075,
+						"max_borrow_limit": 10,
+						"min_borrow_limit": 0.001
+					}
+				}
+			}
+		}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetMarginUserConfig,
+		Timestamp: now.UnixMilli(),
+		Params:    map[string]interface{}{},
+	}).Return(signature, nil)
+
+	config, err := client.GetMarginUserConfig(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, &cdcexchange.MarginUserConfig{
+		StakeAmount: decimal.MustParse("5000"),
+		CurrencyConfigs: map[string]cdcexchange.MarginCurrencyConfig{
+			"BTC": {
+				DailyInterestRate: decimal.MustParse("0.00075"),
+				MaxBorrowLimit:    decimal.MustParse("10"),
+				MinBorrowLimit:    decimal.MustParse("0.001"),
+			},
+		},
+	}, config)
+}
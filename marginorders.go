@@ -0,0 +1,120 @@
+package cdcexchange
+
+import (
+	"context"
+)
+
+const (
+	methodCreateMarginOrder     = "private/margin/create-order"
+	methodCancelMarginOrder     = "private/margin/cancel-order"
+	methodCancelAllMarginOrders = "private/margin/cancel-all-orders"
+	methodGetMarginOrderHistory = "private/margin/get-order-history"
+	methodGetMarginOpenOrders   = "private/margin/get-open-orders"
+	methodGetMarginOrderDetail  = "private/margin/get-order-detail"
+	methodGetMarginTrades       = "private/margin/get-trades"
+)
+
+// CreateMarginOrder creates a new BUY or SELL margin order on the Exchange.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.margin.order subscription can be used to check when the order is successfully created.
+//
+// Method: private/margin/create-order
+func (c *client) CreateMarginOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
+	return c.createOrder(ctx, methodCreateMarginOrder, req)
+}
+
+// CancelMarginOrder cancels an existing margin order on the Exchange.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.margin.order subscription can be used to check when the order is successfully cancelled.
+//
+// Method: private/margin/cancel-order
+func (c *client) CancelMarginOrder(ctx context.Context, instrumentName string, orderID string) error {
+	return c.cancelOrder(ctx, methodCancelMarginOrder, instrumentName, orderID)
+}
+
+// CancelAllMarginOrders cancels all margin orders for a particular instrument/pair.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.margin.order subscription can be used to check when the orders are successfully cancelled.
+//
+// Method: private/margin/cancel-all-orders
+func (c *client) CancelAllMarginOrders(ctx context.Context, instrumentName string) error {
+	return c.cancelAllOrders(ctx, methodCancelAllMarginOrders, instrumentName)
+}
+
+// GetMarginOrderHistory gets the margin order history for a particular instrument.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// req.InstrumentName can be left blank to get orders for all instruments.
+//
+// Method: private/margin/get-order-history
+func (c *client) GetMarginOrderHistory(ctx context.Context, req GetOrderHistoryRequest) (*GetOrderHistoryResult, error) {
+	return c.getOrderHistory(ctx, methodGetMarginOrderHistory, req)
+}
+
+// GetMarginOpenOrders gets all open margin orders for a particular instrument.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+//
+// req.InstrumentName can be left blank to get open orders for all instruments.
+//
+// Method: private/margin/get-open-orders
+func (c *client) GetMarginOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*GetOpenOrdersResult, error) {
+	return c.getOpenOrders(ctx, methodGetMarginOpenOrders, req)
+}
+
+// GetMarginOrderDetail gets details of a margin order for a particular order ID.
+//
+// Method: private/margin/get-order-detail
+func (c *client) GetMarginOrderDetail(ctx context.Context, orderID string) (*GetOrderDetailResult, error) {
+	return c.getOrderDetail(ctx, methodGetMarginOrderDetail, orderID)
+}
+
+// GetMarginTrades gets all executed margin trades for a particular instrument.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// req.InstrumentName can be left blank to get executed trades for all instruments.
+//
+// Method: private/margin/get-trades
+func (c *client) GetMarginTrades(ctx context.Context, req GetTradesRequest) (*GetTradesResult, error) {
+	return c.getTrades(ctx, methodGetMarginTrades, req)
+}
+
+// IterateMarginOrderHistory iterates over the margin order history for a particular instrument, starting from req.Page.
+//
+// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+// req.End defaults to now if only req.Start is set.
+//
+// Method: private/margin/get-order-history
+func (c *client) IterateMarginOrderHistory(ctx context.Context, req GetOrderHistoryRequest) *OrderIterator {
+	return c.iterateOrderHistory(ctx, methodGetMarginOrderHistory, req)
+}
+
+// IterateMarginOpenOrders iterates over all open margin orders for a particular instrument, starting from req.Page.
+//
+// Method: private/margin/get-open-orders
+func (c *client) IterateMarginOpenOrders(ctx context.Context, req GetOpenOrdersRequest) *OrderIterator {
+	return c.iterateOpenOrders(ctx, methodGetMarginOpenOrders, req)
+}
+
+// IterateMarginTrades iterates over all executed margin trades for a particular instrument, starting from req.Page.
+//
+// Ranges between req.Start and req.End longer than 24 hours are split into consecutive 24 hour windows,
+// req.End defaults to now if only req.Start is set.
+//
+// Method: private/margin/get-trades
+func (c *client) IterateMarginTrades(ctx context.Context, req GetTradesRequest) *TradeIterator {
+	return c.iterateTrades(ctx, methodGetMarginTrades, req)
+}
@@ -0,0 +1,182 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_MarginOrders_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrumentName = "BTC_USDT"
+		orderID        = "some order id"
+	)
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		method         string
+		call           func(ctx context.Context, client cdcexchange.MarginTradingAPI) error
+		expectedParams map[string]interface{}
+	}{
+		{
+			name:   "creates a margin order",
+			method: cdcexchange.MethodCreateMarginOrder,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				_, err := client.CreateMarginOrder(ctx, cdcexchange.CreateOrderRequest{
+					InstrumentName: instrumentName,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeMarket,
+					Notional:       decimal.MustParse("100"),
+				})
+				return err
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+				"side":            cdcexchange.OrderSideBuy,
+				"type":            cdcexchange.OrderTypeMarket,
+				"notional":        decimal.MustParse("100"),
+			},
+		},
+		{
+			name:   "cancels a margin order",
+			method: cdcexchange.MethodCancelMarginOrder,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				return client.CancelMarginOrder(ctx, instrumentName, orderID)
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+				"order_id":        orderID,
+			},
+		},
+		{
+			name:   "cancels all margin orders",
+			method: cdcexchange.MethodCancelAllMarginOrders,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				return client.CancelAllMarginOrders(ctx, instrumentName)
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+			},
+		},
+		{
+			name:   "gets margin order history",
+			method: cdcexchange.MethodGetMarginOrderHistory,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				_, err := client.GetMarginOrderHistory(ctx, cdcexchange.GetOrderHistoryRequest{InstrumentName: instrumentName})
+				return err
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+				"page":            0,
+			},
+		},
+		{
+			name:   "gets margin open orders",
+			method: cdcexchange.MethodGetMarginOpenOrders,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				_, err := client.GetMarginOpenOrders(ctx, cdcexchange.GetOpenOrdersRequest{InstrumentName: instrumentName})
+				return err
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+				"page":            0,
+			},
+		},
+		{
+			name:   "gets margin order detail",
+			method: cdcexchange.MethodGetMarginOrderDetail,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				_, err := client.GetMarginOrderDetail(ctx, orderID)
+				return err
+			},
+			expectedParams: map[string]interface{}{
+				"order_id": orderID,
+			},
+		},
+		{
+			name:   "gets margin trades",
+			method: cdcexchange.MethodGetMarginTrades,
+			call: func(ctx context.Context, client cdcexchange.MarginTradingAPI) error {
+				_, err := client.GetMarginTrades(ctx, cdcexchange.GetTradesRequest{InstrumentName: instrumentName})
+				return err
+			},
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrumentName,
+				"page":            0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, tt.method)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, tt.method, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, signature, body.Signature)
+				assert.Len(t, body.Params, len(tt.expectedParams))
+
+				_, err := w.Write([]byte(`{"id": 0, "method": "", "code": 0, "result": {}}`))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    tt.method,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			require.NoError(t, tt.call(ctx, client))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const methodMarginRepay = "private/margin/repay"
+
+// MarginRepayResponse is the base response returned from the private/margin/repay API.
+type MarginRepayResponse struct {
+	// api.BaseResponse is the common response fields.
+	api.BaseResponse
+}
+
+// MarginRepay repays an amount of a particular borrowed currency from the margin wallet.
+//
+// Accrued interest is repaid before the borrowed amount.
+//
+// Method: private/margin/repay
+func (c *client) MarginRepay(ctx context.Context, currency string, amount decimal.Decimal) error {
+	if currency == "" {
+		return errors.InvalidParameterError{Parameter: "currency", Reason: "cannot be empty"}
+	}
+	if amount.Sign() <= 0 {
+		return errors.InvalidParameterError{Parameter: "amount", Reason: "must be greater than 0"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = currency
+	params["amount"] = amount
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodMarginRepay,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodMarginRepay,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var repayResponse MarginRepayResponse
+	statusCode, err := c.requester.WithoutRetries().Post(ctx, body, methodMarginRepay, &repayResponse)
+	if err != nil {
+		return fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, repayResponse.Code); err != nil {
+		return fmt.Errorf("error received in response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodMarginTransfer = "private/margin/transfer"
+
+	MarginWalletSpot   MarginWallet = "SPOT"
+	MarginWalletMargin MarginWallet = "MARGIN"
+)
+
+type (
+	// MarginWallet is a wallet which funds can be transferred to and from (SPOT/MARGIN).
+	MarginWallet string
+
+	// MarginTransferRequest is the request params sent for the private/margin/transfer API.
+	MarginTransferRequest struct {
+		// Currency is the currency to transfer (e.g. USDT).
+		Currency string `json:"currency"`
+		// From is the wallet to transfer from.
+		From MarginWallet `json:"from"`
+		// To is the wallet to transfer to.
+		To MarginWallet `json:"to"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// MarginTransferResponse is the base response returned from the private/margin/transfer API.
+	MarginTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// MarginTransfer transfers funds between the spot and margin wallets.
+//
+// Method: private/margin/transfer
+func (c *client) MarginTransfer(ctx context.Context, req MarginTransferRequest) error {
+	switch {
+	case req.Currency == "":
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	case req.From == "":
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "cannot be empty"}
+	case req.To == "":
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be empty"}
+	case req.Amount.Sign() <= 0:
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = req.Currency
+	params["from"] = req.From
+	params["to"] = req.To
+	params["amount"] = req.Amount
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodMarginTransfer,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodMarginTransfer,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var transferResponse MarginTransferResponse
+	statusCode, err := c.requester.WithoutRetries().Post(ctx, body, methodMarginTransfer, &transferResponse)
+	if err != nil {
+		return fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, transferResponse.Code); err != nil {
+		return fmt.Errorf("error received in response: %w", err)
+	}
+
+	return nil
+}
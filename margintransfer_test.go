@@ -0,0 +1,298 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_MarginTransfer_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	validReq := cdcexchange.MarginTransferRequest{
+		Currency: "USDT",
+		From:     cdcexchange.MarginWalletSpot,
+		To:       cdcexchange.MarginWalletMargin,
+		Amount:   decimal.MustParse("100"),
+	}
+
+	type args struct {
+		req cdcexchange.MarginTransferRequest
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			args: args{
+				req: cdcexchange.MarginTransferRequest{
+					From:   cdcexchange.MarginWalletSpot,
+					To:     cdcexchange.MarginWalletMargin,
+					Amount: decimal.MustParse("100"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when from is empty",
+			args: args{
+				req: cdcexchange.MarginTransferRequest{
+					Currency: "USDT",
+					To:       cdcexchange.MarginWalletMargin,
+					Amount:   decimal.MustParse("100"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.From",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when to is empty",
+			args: args{
+				req: cdcexchange.MarginTransferRequest{
+					Currency: "USDT",
+					From:     cdcexchange.MarginWalletSpot,
+					Amount:   decimal.MustParse("100"),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.To",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when amount is 0",
+			args: args{
+				req: cdcexchange.MarginTransferRequest{
+					Currency: "USDT",
+					From:     cdcexchange.MarginWalletSpot,
+					To:       cdcexchange.MarginWalletMargin,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         args{req: validReq},
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: args{req: validReq},
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: args{req: validReq},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			if tt.req == validReq {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodMarginTransfer,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": validReq.Currency,
+						"from":     validReq.From,
+						"to":       validReq.To,
+						"amount":   validReq.Amount,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			err = client.MarginTransfer(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_MarginTransfer_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		req            cdcexchange.MarginTransferRequest
+		expectedParams map[string]interface{}
+	}{
+		{
+			name: "successfully transfers from spot to margin",
+			req: cdcexchange.MarginTransferRequest{
+				Currency: "USDT",
+				From:     cdcexchange.MarginWalletSpot,
+				To:       cdcexchange.MarginWalletMargin,
+				Amount:   decimal.MustParse("100"),
+			},
+			expectedParams: map[string]interface{}{
+				"currency": "USDT",
+				"from":     cdcexchange.MarginWalletSpot,
+				"to":       cdcexchange.MarginWalletMargin,
+				"amount":   decimal.MustParse("100"),
+			},
+		},
+		{
+			name: "successfully transfers from margin to spot",
+			req: cdcexchange.MarginTransferRequest{
+				Currency: "BTC",
+				From:     cdcexchange.MarginWalletMargin,
+				To:       cdcexchange.MarginWalletSpot,
+				Amount:   decimal.MustParse("0.5"),
+			},
+			expectedParams: map[string]interface{}{
+				"currency": "BTC",
+				"from":     cdcexchange.MarginWalletMargin,
+				"to":       cdcexchange.MarginWalletSpot,
+				"amount":   decimal.MustParse("0.5"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodMarginTransfer)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodMarginTransfer, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Equal(t, tt.req.Currency, body.Params["currency"])
+				assert.Equal(t, string(tt.req.From), body.Params["from"])
+				assert.Equal(t, string(tt.req.To), body.Params["to"])
+				assert.Equal(t, tt.req.Amount.Float64(), body.Params["amount"])
+
+				require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.MarginTransferResponse{}))
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodMarginTransfer,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			require.NoError(t, client.MarginTransfer(ctx, tt.req))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/websocket"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+type (
+	// MarketTrade represents the details of a trade executed on the Exchange by any user.
+	MarketTrade struct {
+		// TradeID is the unique identifier for the trade.
+		TradeID int64 `json:"d"`
+		// InstrumentName represents the currency pair traded (e.g. ETH_CRO or BTC_USDT).
+		InstrumentName string `json:"i"`
+		// Side represents whether the taker side of the trade is buy or sell.
+		Side OrderSide `json:"s"`
+		// Price is the executed trade price.
+		Price decimal.Decimal `json:"p"`
+		// Quantity is the executed trade quantity.
+		Quantity decimal.Decimal `json:"q"`
+		// Timestamp is the trade execution time.
+		Timestamp time.Time `json:"t"`
+	}
+
+	// marketWebsocket is a concrete implementation of MarketWebsocket.
+	marketWebsocket struct {
+		conn   *websocket.Conn
+		events *websocketEvents
+	}
+)
+
+// NewMarketWebsocket opens a new connection to the market data websocket.
+//
+// The connection should be closed once it is no longer needed.
+func (c *client) NewMarketWebsocket(ctx context.Context) (MarketWebsocket, error) {
+	events := newWebsocketEvents()
+
+	conn, err := c.dialWebsocket(ctx, c.marketWebsocketURL, events, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to market websocket: %w", err)
+	}
+
+	return &marketWebsocket{conn: conn, events: events}, nil
+}
+
+// SubscribeBook subscribes to order book updates for a particular instrument and depth.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: book.{instrument_name}.{depth}
+func (m *marketWebsocket) SubscribeBook(ctx context.Context, instrument string, depth int) (<-chan BookResult, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+	if depth <= 0 {
+		return nil, errors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"}
+	}
+
+	return subscribe[BookResult](ctx, m.conn, bookChannel(instrument, depth))
+}
+
+// UnsubscribeBook cancels a subscription to order book updates.
+//
+// Channel: book.{instrument_name}.{depth}
+func (m *marketWebsocket) UnsubscribeBook(ctx context.Context, instrument string, depth int) error {
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+	if depth <= 0 {
+		return errors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"}
+	}
+
+	if err := m.conn.Unsubscribe(ctx, bookChannel(instrument, depth)); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeTicker subscribes to ticker updates for a particular instrument.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: ticker.{instrument_name}
+func (m *marketWebsocket) SubscribeTicker(ctx context.Context, instrument string) (<-chan Ticker, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	return subscribe[Ticker](ctx, m.conn, tickerChannel(instrument))
+}
+
+// UnsubscribeTicker cancels a subscription to ticker updates.
+//
+// Channel: ticker.{instrument_name}
+func (m *marketWebsocket) UnsubscribeTicker(ctx context.Context, instrument string) error {
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	if err := m.conn.Unsubscribe(ctx, tickerChannel(instrument)); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeTrades subscribes to trades executed for a particular instrument.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: trade.{instrument_name}
+func (m *marketWebsocket) SubscribeTrades(ctx context.Context, instrument string) (<-chan MarketTrade, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	return subscribe[MarketTrade](ctx, m.conn, tradeChannel(instrument))
+}
+
+// UnsubscribeTrades cancels a subscription to executed trades.
+//
+// Channel: trade.{instrument_name}
+func (m *marketWebsocket) UnsubscribeTrades(ctx context.Context, instrument string) error {
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	if err := m.conn.Unsubscribe(ctx, tradeChannel(instrument)); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// Events returns a channel on which connection events are delivered (e.g. reconnecting, a missed heartbeat).
+//
+// The channel is closed once the connection is closed.
+func (m *marketWebsocket) Events() <-chan WebsocketEvent {
+	return m.events.ch
+}
+
+// Close closes the connection, along with all of its subscriptions.
+func (m *marketWebsocket) Close() error {
+	return m.conn.Close()
+}
+
+func bookChannel(instrument string, depth int) string {
+	return fmt.Sprintf("book.%s.%d", instrument, depth)
+}
+
+func tickerChannel(instrument string) string {
+	return fmt.Sprintf("ticker.%s", instrument)
+}
+
+func tradeChannel(instrument string) string {
+	return fmt.Sprintf("trade.%s", instrument)
+}
@@ -0,0 +1,373 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_NewMarketWebsocket_Error(t *testing.T) {
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithMarketWebsocketURL("ws://127.0.0.1:0"),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.Error(t, err)
+
+	assert.Nil(t, ws)
+}
+
+func TestMarketWebsocket_SubscribeBook_Error(t *testing.T) {
+	type args struct {
+		instrument string
+		depth      int
+	}
+	tests := []struct {
+		name string
+		args
+		responseCode int64
+		expectedErr  error
+	}{
+		{
+			name: "returns error when instrument is empty",
+			args: args{
+				instrument: "",
+				depth:      10,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when depth is 0",
+			args: args{
+				instrument: "some instrument",
+				depth:      0,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"},
+		},
+		{
+			name: "returns error given error response",
+			args: args{
+				instrument: "some instrument",
+				depth:      10,
+			},
+			responseCode: 10004,
+			expectedErr:  cdcerrors.ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+
+				writeResponse(t, conn, websocketResponse{
+					ID:     req.ID,
+					Method: req.Method,
+					Code:   tt.responseCode,
+				})
+				waitForClose(conn)
+			})
+
+			ws := newMarketWebsocket(t, url)
+
+			books, err := ws.SubscribeBook(context.Background(), tt.instrument, tt.depth)
+			require.Error(t, err)
+
+			assert.Nil(t, books)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+		})
+	}
+}
+
+func TestMarketWebsocket_Unsubscribe_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		unsubscribe func(ctx context.Context, ws cdcexchange.MarketWebsocket) error
+		expectedErr error
+	}{
+		{
+			name: "returns error when book instrument is empty",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.MarketWebsocket) error {
+				return ws.UnsubscribeBook(ctx, "", 10)
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when book depth is 0",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.MarketWebsocket) error {
+				return ws.UnsubscribeBook(ctx, "some instrument", 0)
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "depth", Reason: "must be greater than 0"},
+		},
+		{
+			name: "returns error when ticker instrument is empty",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.MarketWebsocket) error {
+				return ws.UnsubscribeTicker(ctx, "")
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when trades instrument is empty",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.MarketWebsocket) error {
+				return ws.UnsubscribeTrades(ctx, "")
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				// no request is sent given invalid parameters.
+				if req, ok := readRequest(conn); ok {
+					t.Errorf("unexpected request: %s", req.Method)
+				}
+			})
+
+			ws := newMarketWebsocket(t, url)
+
+			err := tt.unsubscribe(context.Background(), ws)
+			require.Error(t, err)
+
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestMarketWebsocket_SubscribeBook_Success(t *testing.T) {
+	const (
+		instrument   = "some instrument"
+		depth        = 10
+		subscription = "book.some instrument.10"
+	)
+	now := time.Now().Round(time.Second)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, "subscribe", req.Method)
+		assert.Equal(t, []interface{}{subscription}, req.Params["channels"])
+
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+		writeResponse(t, conn, websocketResponse{
+			ID:     -1,
+			Method: "subscribe",
+			Result: subscriptionResult{
+				InstrumentName: instrument,
+				Subscription:   subscription,
+				Channel:        "book",
+				Data: []map[string]interface{}{
+					{
+						"bids": [][]decimal.Decimal{{decimal.MustParse("1"), decimal.MustParse("2"), decimal.MustParse("3")}},
+						"asks": [][]decimal.Decimal{{decimal.MustParse("4"), decimal.MustParse("5"), decimal.MustParse("6")}},
+						"t":    now.UnixMilli(),
+					},
+				},
+			},
+		})
+
+		req, ok = readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, "unsubscribe", req.Method)
+		assert.Equal(t, []interface{}{subscription}, req.Params["channels"])
+
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+		waitForClose(conn)
+	})
+
+	ws := newMarketWebsocket(t, url)
+
+	books, err := ws.SubscribeBook(context.Background(), instrument, depth)
+	require.NoError(t, err)
+
+	select {
+	case book := <-books:
+		assert.Equal(t, cdcexchange.BookResult{
+			Bids:      [][]decimal.Decimal{{decimal.MustParse("1"), decimal.MustParse("2"), decimal.MustParse("3")}},
+			Asks:      [][]decimal.Decimal{{decimal.MustParse("4"), decimal.MustParse("5"), decimal.MustParse("6")}},
+			Timestamp: cdctime.Time(now),
+		}, book)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for book")
+	}
+
+	require.NoError(t, ws.UnsubscribeBook(context.Background(), instrument, depth))
+
+	assertClosed(t, books)
+}
+
+func TestMarketWebsocket_SubscribeTicker_Success(t *testing.T) {
+	const (
+		instrument   = "some instrument"
+		subscription = "ticker.some instrument"
+	)
+	now := time.Now().Round(time.Second)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, "subscribe", req.Method)
+		assert.Equal(t, []interface{}{subscription}, req.Params["channels"])
+
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+		writeResponse(t, conn, websocketResponse{
+			ID:     -1,
+			Method: "subscribe",
+			Result: subscriptionResult{
+				InstrumentName: instrument,
+				Subscription:   subscription,
+				Channel:        "ticker",
+				Data: []map[string]interface{}{
+					{
+						"i": instrument,
+						"b": 1.1,
+						"k": 2.2,
+						"a": 3.3,
+						"t": now.UnixMilli(),
+					},
+				},
+			},
+		})
+		waitForClose(conn)
+	})
+
+	ws := newMarketWebsocket(t, url)
+
+	tickers, err := ws.SubscribeTicker(context.Background(), instrument)
+	require.NoError(t, err)
+
+	select {
+	case ticker := <-tickers:
+		assert.Equal(t, cdcexchange.Ticker{
+			Instrument:       instrument,
+			BidPrice:         decimal.MustParse("1.1"),
+			AskPrice:         decimal.MustParse("2.2"),
+			LatestTradePrice: decimal.MustParse("3.3"),
+			Timestamp:        cdctime.Time(now),
+		}, ticker)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker")
+	}
+
+	require.NoError(t, ws.Close())
+
+	assertClosed(t, tickers)
+}
+
+func TestMarketWebsocket_SubscribeTrades_Success(t *testing.T) {
+	const (
+		instrument   = "some instrument"
+		subscription = "trade.some instrument"
+	)
+	var (
+		now      = time.Now().Round(time.Second)
+		received = make(chan struct{})
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, "subscribe", req.Method)
+		assert.Equal(t, []interface{}{subscription}, req.Params["channels"])
+
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+		writeResponse(t, conn, websocketResponse{
+			ID:     -1,
+			Method: "subscribe",
+			Result: subscriptionResult{
+				InstrumentName: instrument,
+				Subscription:   subscription,
+				Channel:        "trade",
+				Data: []map[string]interface{}{
+					{
+						"d": 1234,
+						"i": instrument,
+						"s": "BUY",
+						"p": 1.1,
+						"q": 2.2,
+						"t": now.UnixMilli(),
+					},
+				},
+			},
+		})
+
+		<-received
+	})
+
+	ws := newMarketWebsocket(t, url)
+
+	trades, err := ws.SubscribeTrades(context.Background(), instrument)
+	require.NoError(t, err)
+
+	select {
+	case trade := <-trades:
+		assert.Equal(t, cdcexchange.MarketTrade{
+			TradeID:        1234,
+			InstrumentName: instrument,
+			Side:           cdcexchange.OrderSideBuy,
+			Price:          decimal.MustParse("1.1"),
+			Quantity:       decimal.MustParse("2.2"),
+			Timestamp:      cdctime.Time(now),
+		}, trade)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for trade")
+	}
+	close(received)
+
+	// the server closing the connection closes the subscription.
+	assertClosed(t, trades)
+}
+
+// newMarketWebsocket connects to the market websocket at url, lost connections are not re-established.
+func newMarketWebsocket(t *testing.T, url string) cdcexchange.MarketWebsocket {
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithClock(clockwork.NewFakeClock()),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnectDisabled(),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	return ws
+}
+
+// assertClosed drains the channel, asserting it is closed.
+func assertClosed(t *testing.T, ch interface{}) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		drain(ch)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
@@ -0,0 +1,96 @@
+package cdcexchange
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/ratelimit"
+)
+
+// RateLimit is the maximum number of requests which can be made to a method within an interval.
+type RateLimit struct {
+	// Requests is the number of requests allowed per Interval, requests can be made in bursts of up to this size.
+	Requests int
+	// Interval is the period over which Requests are allowed.
+	Interval time.Duration
+}
+
+var (
+	// defaultRateLimit is applied to any private method not in defaultRateLimits.
+	defaultRateLimit = RateLimit{Requests: 3, Interval: 100 * time.Millisecond}
+
+	// defaultRateLimits are the limits published by the Exchange.
+	defaultRateLimits = map[string]RateLimit{
+		methodCreateOrder:           {Requests: 15, Interval: 100 * time.Millisecond},
+		methodCancelOrder:           {Requests: 15, Interval: 100 * time.Millisecond},
+		methodCancelAllOrders:       {Requests: 15, Interval: 100 * time.Millisecond},
+		methodCreateMarginOrder:     {Requests: 15, Interval: 100 * time.Millisecond},
+		methodCancelMarginOrder:     {Requests: 15, Interval: 100 * time.Millisecond},
+		methodCancelAllMarginOrders: {Requests: 15, Interval: 100 * time.Millisecond},
+		methodGetOrderDetail:        {Requests: 30, Interval: 100 * time.Millisecond},
+		methodGetMarginOrderDetail:  {Requests: 30, Interval: 100 * time.Millisecond},
+		methodGetTrades:             {Requests: 1, Interval: time.Second},
+		methodGetMarginTrades:       {Requests: 1, Interval: time.Second},
+		methodGetOrderHistory:       {Requests: 1, Interval: time.Second},
+		methodGetMarginOrderHistory: {Requests: 1, Interval: time.Second},
+
+		methodGetInstruments:              {Requests: 100, Interval: time.Second},
+		methodGetBook:                     {Requests: 100, Interval: time.Second},
+		methodGetTicker:                   {Requests: 100, Interval: time.Second},
+		methodGetCandlestick:              {Requests: 100, Interval: time.Second},
+		methodGetPublicTrades:             {Requests: 100, Interval: time.Second},
+		methodGetMarginTransferCurrencies: {Requests: 100, Interval: time.Second},
+		methodGetMarginLoanCurrencies:     {Requests: 100, Interval: time.Second},
+	}
+)
+
+// WithRateLimits overrides the rate limits of the specified methods (e.g. private/create-order),
+// the Exchange's published limits are used for all other methods.
+//
+// Rate limiting is enabled if it was previously disabled with WithRateLimitDisabled.
+func WithRateLimits(limits map[string]RateLimit) ClientOption {
+	return func(c *client) error {
+		for method, limit := range limits {
+			switch {
+			case method == "":
+				return errors.InvalidParameterError{Parameter: "limits", Reason: "method cannot be empty"}
+			case limit.Requests <= 0:
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("limits[%s].Requests", method), Reason: "must be greater than 0"}
+			case limit.Interval <= 0:
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("limits[%s].Interval", method), Reason: "must be greater than 0"}
+			}
+		}
+
+		for method, limit := range limits {
+			c.rateLimits[method] = limit
+		}
+		c.rateLimitDisabled = false
+		c.rateLimitsUpdated = true
+		return nil
+	}
+}
+
+// WithRateLimitDisabled stops requests from being rate limited by the client,
+// requests which exceed the Exchange's limits will fail with errors.ErrTooManyRequests.
+func WithRateLimitDisabled() ClientOption {
+	return func(c *client) error {
+		c.rateLimitDisabled = true
+		c.rateLimitsUpdated = true
+		return nil
+	}
+}
+
+// newRateLimiter creates a limiter from the client's configured rate limits, nil is returned if rate limiting is disabled.
+func (c *client) newRateLimiter() *ratelimit.Limiter {
+	if c.rateLimitDisabled {
+		return nil
+	}
+
+	limits := make(map[string]ratelimit.Limit, len(c.rateLimits))
+	for method, limit := range c.rateLimits {
+		limits[method] = ratelimit.Limit(limit)
+	}
+
+	return ratelimit.New(c.clock, limits, ratelimit.Limit(defaultRateLimit))
+}
@@ -0,0 +1,130 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+)
+
+func TestClient_RateLimit(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		call   func(ctx context.Context, client cdcexchange.CommonAPI) error
+	}{
+		{
+			name:   "limits requests made with requester",
+			method: cdcexchange.MethodGetInstruments,
+			call: func(ctx context.Context, client cdcexchange.CommonAPI) error {
+				_, err := client.GetInstruments(ctx)
+				return err
+			},
+		},
+		{
+			name:   "limits requests made with query parameters",
+			method: cdcexchange.MethodGetBook,
+			call: func(ctx context.Context, client cdcexchange.CommonAPI) error {
+				_, err := client.GetBook(ctx, "some instrument", 0)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+
+				_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{}}`))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			newClient := func(opts ...cdcexchange.ClientOption) cdcexchange.CryptoDotComExchange {
+				client, err := cdcexchange.New(apiKey, secretKey, append([]cdcexchange.ClientOption{
+					cdcexchange.WithClock(clockwork.NewFakeClock()),
+					cdcexchange.WithHTTPClient(s.Client()),
+					cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+					cdcexchange.WithRateLimits(map[string]cdcexchange.RateLimit{
+						tt.method: {Requests: 1, Interval: time.Minute},
+					}),
+				}, opts...)...)
+				require.NoError(t, err)
+
+				return client
+			}
+
+			t.Run("returns context error once limit is exceeded", func(t *testing.T) {
+				atomic.StoreInt32(&requests, 0)
+				client := newClient()
+
+				require.NoError(t, tt.call(context.Background(), client))
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				err := tt.call(ctx, client)
+				require.Error(t, err)
+
+				assert.True(t, errors.Is(err, context.DeadlineExceeded))
+				assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+			})
+
+			t.Run("keeps limit when config is updated without rate limit options", func(t *testing.T) {
+				atomic.StoreInt32(&requests, 0)
+				client := newClient()
+
+				require.NoError(t, tt.call(context.Background(), client))
+				require.NoError(t, client.UpdateConfig("some other api key", "some other secret key"))
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				err := tt.call(ctx, client)
+				require.Error(t, err)
+
+				assert.True(t, errors.Is(err, context.DeadlineExceeded))
+				assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+			})
+
+			t.Run("does not limit requests once disabled by updated config", func(t *testing.T) {
+				atomic.StoreInt32(&requests, 0)
+				client := newClient()
+
+				require.NoError(t, tt.call(context.Background(), client))
+				require.NoError(t, client.UpdateConfig(apiKey, secretKey, cdcexchange.WithRateLimitDisabled()))
+				require.NoError(t, tt.call(context.Background(), client))
+
+				assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+			})
+
+			t.Run("does not limit requests if disabled", func(t *testing.T) {
+				atomic.StoreInt32(&requests, 0)
+				client := newClient(cdcexchange.WithRateLimitDisabled())
+
+				for i := 0; i < 3; i++ {
+					require.NoError(t, tt.call(context.Background(), client))
+				}
+
+				assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+			})
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cdcexchange
+
+import (
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+)
+
+// RetryPolicy configures how requests which fail with a transient error are retried.
+//
+// Network errors, 5xx status codes, errors.ErrSystemError and errors.ErrTooManyRequests are considered transient.
+// Other errors, such as an error returned by an Interceptor, are returned without retrying.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried, requests are not retried if 0.
+	MaxRetries int
+	// MinBackoff & MaxBackoff bound the delay between attempts, the delay doubles with each attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// WithRetryPolicy enables retries of requests which fail with a transient error (Default: requests are not retried).
+//
+// Requests which are not safe to repeat are never retried. Orders are only retried if the request has a ClientOID
+// and withdrawals if the request has a ClientWID, transfers, borrows & repayments are not retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *client) error {
+		switch {
+		case policy.MaxRetries < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MaxRetries", Reason: "cannot be less than 0"}
+		case policy.MaxRetries > 0 && policy.MinBackoff <= 0:
+			return errors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "must be greater than 0"}
+		case policy.MaxBackoff < policy.MinBackoff:
+			return errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"}
+		}
+
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// newRetryPolicy creates a retry policy from the client's configuration, nil is returned if retries are disabled.
+func (c *client) newRetryPolicy() *api.RetryPolicy {
+	if c.retryPolicy.MaxRetries == 0 {
+		return nil
+	}
+
+	return &api.RetryPolicy{
+		Clock:      c.clock,
+		MaxRetries: c.retryPolicy.MaxRetries,
+		MinBackoff: c.retryPolicy.MinBackoff,
+		MaxBackoff: c.retryPolicy.MaxBackoff,
+	}
+}
@@ -0,0 +1,279 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+)
+
+type retryResponse struct {
+	statusCode int
+	code       int
+	// drop closes the connection without a response.
+	drop bool
+}
+
+func TestClient_Retry(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		maxBackoff = 10 * time.Second
+	)
+
+	var (
+		ok              = retryResponse{statusCode: http.StatusOK}
+		unavailable     = retryResponse{statusCode: http.StatusServiceUnavailable, code: 10001}
+		systemError     = retryResponse{statusCode: http.StatusBadRequest, code: 10001}
+		tooManyRequests = retryResponse{statusCode: http.StatusTooManyRequests, code: 10006}
+		illegalIP       = retryResponse{statusCode: http.StatusUnauthorized, code: 10003}
+		dropped         = retryResponse{drop: true}
+
+		getInstruments = func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+			_, err := client.GetInstruments(ctx)
+			return err
+		}
+		getBook = func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+			_, err := client.GetBook(ctx, "some instrument", 0)
+			return err
+		}
+		createOrder = func(clientOID string) func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+			return func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+				_, err := client.CreateOrder(ctx, cdcexchange.CreateOrderRequest{
+					InstrumentName: "some instrument",
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeMarket,
+					Notional:       decimal.MustParse("100"),
+					ClientOID:      clientOID,
+				})
+				return err
+			}
+		}
+		marginTransfer = func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+			return client.MarginTransfer(ctx, cdcexchange.MarginTransferRequest{
+				Currency: "USDT",
+				From:     cdcexchange.MarginWalletSpot,
+				To:       cdcexchange.MarginWalletMargin,
+				Amount:   decimal.MustParse("100"),
+			})
+		}
+	)
+
+	tests := []struct {
+		name             string
+		responses        []retryResponse
+		call             func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			name:             "retries 5xx status code until successful",
+			responses:        []retryResponse{unavailable, unavailable, ok},
+			call:             getInstruments,
+			expectedRequests: 3,
+		},
+		{
+			name:             "retries system error",
+			responses:        []retryResponse{systemError, ok},
+			call:             getInstruments,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retries too many requests",
+			responses:        []retryResponse{tooManyRequests, ok},
+			call:             getInstruments,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retries network error",
+			responses:        []retryResponse{dropped, ok},
+			call:             getInstruments,
+			expectedRequests: 2,
+		},
+		{
+			name:             "retries request with query parameters",
+			responses:        []retryResponse{unavailable, ok},
+			call:             getBook,
+			expectedRequests: 2,
+		},
+		{
+			name:             "returns error once max retries are exceeded",
+			responses:        []retryResponse{unavailable, unavailable, unavailable, unavailable},
+			call:             getInstruments,
+			expectedRequests: 3,
+			expectedErr:      cdcerrors.ErrSystemError,
+		},
+		{
+			name:             "does not retry error which is not transient",
+			responses:        []retryResponse{illegalIP, ok},
+			call:             getInstruments,
+			expectedRequests: 1,
+			expectedErr:      cdcerrors.ErrIllegalIP,
+		},
+		{
+			name:             "retries order with client order ID",
+			responses:        []retryResponse{unavailable, ok},
+			call:             createOrder("some client oid"),
+			expectedRequests: 2,
+		},
+		{
+			name:             "does not retry order without client order ID",
+			responses:        []retryResponse{unavailable, ok},
+			call:             createOrder(""),
+			expectedRequests: 1,
+			expectedErr:      cdcerrors.ErrSystemError,
+		},
+		{
+			name:             "does not retry transfer",
+			responses:        []retryResponse{unavailable, ok},
+			call:             marginTransfer,
+			expectedRequests: 1,
+			expectedErr:      cdcerrors.ErrSystemError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := atomic.AddInt32(&requests, 1) - 1
+				res := tt.responses[i]
+
+				if r.Method == http.MethodPost {
+					// the body must be sent with each attempt.
+					assert.NotZero(t, r.ContentLength)
+				}
+
+				if res.drop {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					require.NoError(t, err)
+					require.NoError(t, conn.Close())
+					return
+				}
+
+				w.WriteHeader(res.statusCode)
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":1,"method":"","code":%d,"result":{}}`, res.code)))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			clock := clockwork.NewFakeClock()
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{
+					MaxRetries: 2,
+					MinBackoff: time.Second,
+					MaxBackoff: maxBackoff,
+				}),
+			)
+			require.NoError(t, err)
+
+			done := make(chan error)
+			go func() {
+				done <- tt.call(context.Background(), client)
+			}()
+
+			for i := 1; i < tt.expectedRequests; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(maxBackoff)
+			}
+
+			select {
+			case err := <-done:
+				if tt.expectedErr == nil {
+					require.NoError(t, err)
+				} else {
+					require.Error(t, err)
+					assert.True(t, errors.Is(err, tt.expectedErr))
+				}
+			case <-time.After(time.Second):
+				t.Fatal("request not completed")
+			}
+
+			assert.Equal(t, int32(tt.expectedRequests), atomic.LoadInt32(&requests))
+		})
+	}
+
+	t.Run("returns last error if context is done while waiting to retry", func(t *testing.T) {
+		var requests int32
+
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, err := w.Write([]byte(`{"id":1,"method":"","code":10001,"result":{}}`))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+
+		client, err := cdcexchange.New(apiKey, secretKey,
+			cdcexchange.WithClock(clockwork.NewFakeClock()),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{
+				MaxRetries: 2,
+				MinBackoff: time.Second,
+				MaxBackoff: maxBackoff,
+			}),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = client.GetInstruments(ctx)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, cdcerrors.ErrSystemError))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("does not retry error returned by interceptor", func(t *testing.T) {
+		var requests, intercepted int32
+
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+		}))
+		t.Cleanup(s.Close)
+
+		interceptorErr := errors.New("some error")
+
+		client, err := cdcexchange.New(apiKey, secretKey,
+			cdcexchange.WithClock(clockwork.NewFakeClock()),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithRetryPolicy(cdcexchange.RetryPolicy{
+				MaxRetries: 2,
+				MinBackoff: time.Second,
+				MaxBackoff: maxBackoff,
+			}),
+			cdcexchange.WithInterceptors(func(ctx context.Context, call cdcexchange.Call, invoke cdcexchange.Invoker) (cdcexchange.CallResult, error) {
+				atomic.AddInt32(&intercepted, 1)
+				return cdcexchange.CallResult{}, interceptorErr
+			}),
+		)
+		require.NoError(t, err)
+
+		_, err = client.GetInstruments(context.Background())
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, interceptorErr))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&intercepted))
+		assert.Zero(t, atomic.LoadInt32(&requests))
+	})
+}
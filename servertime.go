@@ -0,0 +1,218 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/flight"
+)
+
+// serverTimeOffset is the estimated offset of the Exchange's clock from the local clock.
+type serverTimeOffset struct {
+	mu     sync.RWMutex
+	offset time.Duration
+	// syncedAt is the local time the offset was last estimated.
+	syncedAt time.Time
+
+	// syncs shares a sync of the offset between the callers which need it.
+	syncs flight.Group
+}
+
+func (o *serverTimeOffset) get() time.Duration {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.offset
+}
+
+func (o *serverTimeOffset) set(offset time.Duration, syncedAt time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.offset = offset
+	o.syncedAt = syncedAt
+}
+
+func (o *serverTimeOffset) lastSynced() time.Time {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.syncedAt
+}
+
+const (
+	// serverTimeInstrument is the instrument whose order book is fetched to sample the Exchange's clock.
+	serverTimeInstrument = "BTC_USDT"
+
+	// serverTimeSamples is the number of times the Exchange's clock is sampled by SyncServerTime,
+	// the sample with the shortest round trip gives the most accurate estimate.
+	serverTimeSamples = 3
+
+	// maxServerTimeRoundTrip is the longest round trip of a sample which is used to estimate the offset,
+	// the error of an estimate is up to half of its round trip.
+	maxServerTimeRoundTrip = 2 * time.Second
+
+	// serverTimeSyncTimeout bounds a sync, which is not cancelled with the caller which started it.
+	serverTimeSyncTimeout = 30 * time.Second
+
+	// minServerTimeResyncInterval is the minimum time between syncs triggered by requests rejected with an invalid nonce,
+	// requests rejected shortly after a sync are retried with the offset it estimated.
+	minServerTimeResyncInterval = 5 * time.Second
+)
+
+// serverTimeSample is a timestamp of the Exchange along with the local time of the HTTP round trip it was returned by.
+type serverTimeSample struct {
+	sent     time.Time
+	received time.Time
+	// bookTime is the timestamp of the order book returned.
+	bookTime time.Time
+	// date is the Date header of the response, which is truncated to the second.
+	date time.Time
+}
+
+// roundTrip returns the time taken to receive the response.
+func (s serverTimeSample) roundTrip() time.Duration {
+	return s.received.Sub(s.sent)
+}
+
+// serverTime returns the time of the Exchange's clock while the request was handled, false if the response had none.
+//
+// The order book's timestamp is used if it was taken while the request was handled, it is stale if it is older
+// than the Date header by more than the round trip (e.g. the book of a quiet market), in which case the Date header
+// is used instead, from halfway through the second it is truncated to.
+func (s serverTimeSample) serverTime() (time.Time, bool) {
+	switch {
+	case s.date.IsZero():
+		return s.bookTime, !s.bookTime.IsZero()
+	case s.bookTime.IsZero(), s.date.Sub(s.bookTime) > s.roundTrip():
+		return s.date.Add(time.Second / 2), true
+	default:
+		return s.bookTime, true
+	}
+}
+
+// offset estimates the offset of the Exchange's clock from the local clock,
+// assuming the timestamp was taken halfway through the round trip.
+func (s serverTimeSample) offset() time.Duration {
+	serverTime, _ := s.serverTime()
+	return serverTime.Sub(s.sent.Add(s.roundTrip() / 2))
+}
+
+// SyncServerTime estimates the offset of the Exchange's clock from the local clock,
+// so that the nonce of each request is within the window accepted by the Exchange.
+//
+// The Exchange's clock is sampled several times, the offset is estimated from the sample with the shortest round trip.
+// Concurrent syncs are shared, a sync which is already in flight is waited for rather than starting another.
+// The clock is re-synced automatically if a request is rejected with errors.ErrInvalidNonce.
+//
+// Method: public/get-book
+func (c *client) SyncServerTime(ctx context.Context) error {
+	return c.serverTimeOffset.syncs.Do(ctx, serverTimeSyncTimeout, c.syncServerTime)
+}
+
+func (c *client) syncServerTime(ctx context.Context) error {
+	var (
+		best  serverTimeSample
+		found bool
+	)
+	for i := 0; i < serverTimeSamples; i++ {
+		sample, err := c.sampleServerTime(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get server time: %w", err)
+		}
+
+		if _, ok := sample.serverTime(); !ok || sample.roundTrip() > maxServerTimeRoundTrip {
+			continue
+		}
+		if !found || sample.roundTrip() < best.roundTrip() {
+			best, found = sample, true
+		}
+	}
+	if !found {
+		return fmt.Errorf("failed to get server time: no response within %s", maxServerTimeRoundTrip)
+	}
+
+	c.serverTimeOffset.set(best.offset(), c.clock.Now())
+
+	return nil
+}
+
+// sampleServerTime fetches the time of the Exchange's clock from the order book of serverTimeInstrument
+// & the Date header of the response.
+// Only the HTTP round trip which returned the book is timed, so that time spent waiting for the rate limit
+// or between retries is not mistaken for latency.
+func (c *client) sampleServerTime(ctx context.Context) (serverTimeSample, error) {
+	var sample serverTimeSample
+
+	requester := c.requester
+	requester.Interceptors = append(append([]api.Interceptor(nil), c.requester.Interceptors...),
+		func(ctx context.Context, call api.Call, invoke api.Invoker) (api.CallResult, error) {
+			sample.sent = c.clock.Now()
+			result, err := invoke(ctx, call)
+			sample.received = c.clock.Now()
+
+			sample.date = time.Time{}
+			if date, err := http.ParseTime(result.Header.Get("Date")); err == nil {
+				sample.date = date
+			}
+
+			return result, err
+		},
+	)
+
+	book, err := c.getBook(ctx, requester, serverTimeInstrument, 1)
+	if err != nil {
+		return serverTimeSample{}, err
+	}
+	sample.bookTime = book.Timestamp.Time()
+
+	return sample, nil
+}
+
+// ServerTimeOffset returns the estimated offset of the Exchange's clock from the local clock.
+func (c *client) ServerTimeOffset() time.Duration {
+	return c.serverTimeOffset.get()
+}
+
+// nonce returns the nonce of a request, the current time in milliseconds according to the Exchange's clock.
+func (c *client) nonce() int64 {
+	return c.clock.Now().Add(c.serverTimeOffset.get()).UnixMilli()
+}
+
+// resyncNonce syncs with the Exchange's clock, then updates the nonce & signature of a request rejected with an invalid nonce.
+//
+// The clock is not synced again if it was synced recently, so that many requests rejected at once cause a single sync.
+func (c *client) resyncNonce(ctx context.Context, req api.Request) (api.Request, error) {
+	if c.clock.Since(c.serverTimeOffset.lastSynced()) >= minServerTimeResyncInterval {
+		if err := c.SyncServerTime(ctx); err != nil {
+			return api.Request{}, err
+		}
+	}
+
+	req.Nonce = c.nonce()
+
+	// public requests are not signed.
+	if req.Signature == "" {
+		return req, nil
+	}
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        req.ID,
+		Method:    req.Method,
+		Timestamp: req.Nonce,
+		Params:    req.Params,
+	})
+	if err != nil {
+		return api.Request{}, fmt.Errorf("failed to create signature: %w", err)
+	}
+	req.Signature = signature
+
+	return req, nil
+}
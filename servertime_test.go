@@ -0,0 +1,428 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+)
+
+func TestClient_SyncServerTime_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, err := w.Write([]byte(`{"id":0,"method":"","code":10003}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	err = client.SyncServerTime(context.Background())
+	require.Error(t, err)
+
+	assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+	assert.Zero(t, client.ServerTimeOffset())
+}
+
+func TestClient_SyncServerTime_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		skew      = 45 * time.Second
+	)
+	now := time.Now().Round(time.Second)
+	serverTime := now.Add(skew)
+
+	tests := []struct {
+		name           string
+		sync           func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error
+		expectedOffset time.Duration
+	}{
+		{
+			name: "syncs explicitly",
+			sync: func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+				return client.SyncServerTime(ctx)
+			},
+			expectedOffset: skew,
+		},
+		{
+			name: "does not sync from order book",
+			sync: func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+				_, err := client.GetBook(ctx, "some instrument", 0)
+				return err
+			},
+		},
+		{
+			name: "does not sync from ticker",
+			sync: func(ctx context.Context, client cdcexchange.CryptoDotComExchange) error {
+				_, err := client.GetTickers(ctx, "some instrument")
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/"+cdcexchange.MethodGetTicker, func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"data":{"i":"BTC_USDT","t":%d}}}`, serverTime.UnixMilli())))
+				require.NoError(t, err)
+			})
+			mux.HandleFunc("/"+cdcexchange.MethodGetBook, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"bids":[],"asks":[],"t":%d}}`, serverTime.UnixMilli())))
+				require.NoError(t, err)
+			})
+			mux.HandleFunc("/"+cdcexchange.MethodGetAccountSummary, func(w http.ResponseWriter, r *http.Request) {
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, now.Add(tt.expectedOffset).UnixMilli(), body.Nonce)
+
+				_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{"accounts":[]}}`))
+				require.NoError(t, err)
+			})
+
+			s := httptest.NewServer(mux)
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clockwork.NewFakeClockAt(now)),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, tt.sync(context.Background(), client))
+
+			assert.Equal(t, tt.expectedOffset, client.ServerTimeOffset())
+
+			// the offset is applied to the nonce of subsequent requests.
+			_, err = client.GetAccountSummary(context.Background(), "")
+			require.NoError(t, err)
+		})
+	}
+}
+
+// serverTimeSample is the round trip of a request to the order book
+// & the error of the book's timestamp from the Exchange's clock halfway through the round trip.
+type serverTimeSample struct {
+	roundTrip time.Duration
+	err       time.Duration
+}
+
+// roundTripTransport advances the clock by the round trip of each sample while the request is in flight.
+type roundTripTransport struct {
+	clock    *clockwork.FakeClock
+	samples  []serverTimeSample
+	requests *int32
+	base     http.RoundTripper
+}
+
+func (t roundTripTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	i := atomic.LoadInt32(t.requests)
+	t.clock.Advance(t.samples[i].roundTrip)
+	return t.base.RoundTrip(r)
+}
+
+func TestClient_SyncServerTime_Samples(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		skew      = 45 * time.Second
+	)
+
+	tests := []struct {
+		name           string
+		samples        []serverTimeSample
+		expectedOffset time.Duration
+		expectedErr    bool
+	}{
+		{
+			name: "uses sample with the shortest round trip",
+			samples: []serverTimeSample{
+				{roundTrip: time.Second, err: 400 * time.Millisecond},
+				{roundTrip: 100 * time.Millisecond},
+				{roundTrip: 500 * time.Millisecond, err: -200 * time.Millisecond},
+			},
+			expectedOffset: skew,
+		},
+		{
+			name: "ignores samples with too long a round trip",
+			samples: []serverTimeSample{
+				{roundTrip: 3 * time.Second},
+				{roundTrip: 1500 * time.Millisecond, err: 100 * time.Millisecond},
+				{roundTrip: 10 * time.Second},
+			},
+			expectedOffset: skew + 100*time.Millisecond,
+		},
+		{
+			name: "uses Date header if the order book is stale",
+			samples: []serverTimeSample{
+				{roundTrip: 100 * time.Millisecond, err: -10 * time.Minute},
+				{roundTrip: time.Second, err: -10 * time.Minute},
+				{roundTrip: time.Second, err: -10 * time.Minute},
+			},
+			// the Date header is truncated to the second, so it is taken to be from halfway through the second.
+			expectedOffset: skew + 450*time.Millisecond,
+		},
+		{
+			name: "returns error if every sample has too long a round trip",
+			samples: []serverTimeSample{
+				{roundTrip: 3 * time.Second},
+				{roundTrip: 3 * time.Second},
+				{roundTrip: 3 * time.Second},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				clock    = clockwork.NewFakeClockAt(time.Now().Round(time.Second))
+				requests int32
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sample := tt.samples[atomic.AddInt32(&requests, 1)-1]
+
+				assert.Equal(t, "BTC_USDT", r.URL.Query().Get("instrument_name"))
+
+				// the clock has been advanced by the round trip, so the timestamp is taken from halfway through it.
+				serverTime := clock.Now().Add(-sample.roundTrip / 2).Add(skew)
+
+				w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"bids":[],"asks":[],"t":%d}}`, serverTime.Add(sample.err).UnixMilli())))
+				require.NoError(t, err)
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&http.Client{
+					Transport: roundTripTransport{clock: clock, samples: tt.samples, requests: &requests, base: s.Client().Transport},
+				}),
+				cdcexchange.WithBaseURL(s.URL),
+			)
+			require.NoError(t, err)
+
+			err = client.SyncServerTime(context.Background())
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, int32(len(tt.samples)), atomic.LoadInt32(&requests))
+			assert.Equal(t, tt.expectedOffset, client.ServerTimeOffset())
+		})
+	}
+}
+
+func TestClient_InvalidNonce(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+		resigned  = "some other signature"
+		skew      = -time.Minute
+		bookPath  = "/" + cdcexchange.MethodGetBook
+	)
+	now := time.Now().Round(time.Second)
+	serverTime := now.Add(skew)
+
+	tests := []struct {
+		name             string
+		resyncedCode     int
+		expectedRequests int32
+		expectedErr      error
+	}{
+		{
+			name:             "resyncs and retries request rejected with invalid nonce",
+			resyncedCode:     0,
+			expectedRequests: 2,
+		},
+		{
+			name:             "returns error if request is rejected again once resynced",
+			resyncedCode:     10007,
+			expectedRequests: 2,
+			expectedErr:      cdcerrors.ErrInvalidNonce,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				requests           int32
+			)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(bookPath, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"bids":[],"asks":[],"t":%d}}`, serverTime.UnixMilli())))
+				require.NoError(t, err)
+			})
+			mux.HandleFunc("/"+cdcexchange.MethodGetAccountSummary, func(w http.ResponseWriter, r *http.Request) {
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, id, body.ID)
+
+				if atomic.AddInt32(&requests, 1) == 1 {
+					assert.Equal(t, now.UnixMilli(), body.Nonce)
+					assert.Equal(t, signature, body.Signature)
+
+					w.WriteHeader(http.StatusBadRequest)
+					_, err := w.Write([]byte(`{"id":1234,"method":"","code":10007}`))
+					require.NoError(t, err)
+					return
+				}
+
+				assert.Equal(t, serverTime.UnixMilli(), body.Nonce)
+				assert.Equal(t, resigned, body.Signature)
+
+				if tt.resyncedCode != 0 {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id":1234,"method":"","code":%d,"result":{"accounts":[{"currency":"CRO"}]}}`, tt.resyncedCode)))
+				require.NoError(t, err)
+			})
+
+			s := httptest.NewServer(mux)
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clockwork.NewFakeClockAt(now)),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			gomock.InOrder(
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetAccountSummary,
+					Timestamp: now.UnixMilli(),
+					Params:    map[string]interface{}{},
+				}).Return(signature, nil),
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetAccountSummary,
+					Timestamp: serverTime.UnixMilli(),
+					Params:    map[string]interface{}{},
+				}).Return(resigned, nil),
+			)
+
+			accounts, err := client.GetAccountSummary(ctx, "")
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []cdcexchange.Account{{Currency: "CRO"}}, accounts)
+			}
+
+			assert.Equal(t, tt.expectedRequests, atomic.LoadInt32(&requests))
+			assert.Equal(t, skew, client.ServerTimeOffset())
+		})
+	}
+}
+
+func TestClient_InvalidNonce_Concurrent(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		skew      = -time.Minute
+		requests  = 5
+	)
+	now := time.Now().Round(time.Second)
+	serverTime := now.Add(skew)
+
+	var samples int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+cdcexchange.MethodGetBook, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&samples, 1)
+
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"bids":[],"asks":[],"t":%d}}`, serverTime.UnixMilli())))
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/"+cdcexchange.MethodGetAccountSummary, func(w http.ResponseWriter, r *http.Request) {
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if body.Nonce != serverTime.UnixMilli() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"id":0,"method":"","code":10007}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{"accounts":[]}}`))
+		require.NoError(t, err)
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithClock(clockwork.NewFakeClockAt(now)),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRateLimitDisabled(),
+	)
+	require.NoError(t, err)
+
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		go func() {
+			_, err := client.GetAccountSummary(context.Background(), "")
+			errs <- err
+		}()
+	}
+	for i := 0; i < requests; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// the requests rejected at once share a single sync.
+	assert.Equal(t, int32(3), atomic.LoadInt32(&samples))
+	assert.Equal(t, skew, client.ServerTimeOffset())
+}
@@ -0,0 +1,116 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+const (
+	methodGetSubAccounts = "private/subaccount/get-sub-accounts"
+)
+
+type (
+	// GetSubAccountsResponse is the base response returned from the private/subaccount/get-sub-accounts API.
+	GetSubAccountsResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetSubAccountsResult `json:"result"`
+	}
+
+	// GetSubAccountsResult is the result returned from the private/subaccount/get-sub-accounts API.
+	GetSubAccountsResult struct {
+		// SubAccountList is the array of sub-accounts.
+		SubAccountList []SubAccount `json:"sub_account_list"`
+	}
+
+	// SubAccount represents the details of a sub-account.
+	SubAccount struct {
+		// UUID is the unique identifier of the sub-account.
+		UUID string `json:"uuid"`
+		// MasterAccountUUID is the unique identifier of the master account.
+		MasterAccountUUID string `json:"master_account_uuid"`
+		// MarginAccountUUID is the unique identifier of the sub-account's margin account (if enabled).
+		MarginAccountUUID string `json:"margin_account_uuid"`
+		// Label is the label given to the sub-account.
+		Label string `json:"label"`
+		// Enabled is true if the sub-account is enabled.
+		Enabled bool `json:"enabled"`
+		// Tradable is true if the sub-account can trade.
+		Tradable bool `json:"tradable"`
+		// Name is the name of the sub-account holder.
+		Name string `json:"name"`
+		// Email is the email address of the sub-account.
+		Email string `json:"email"`
+		// MobileNumber is the mobile number of the sub-account holder.
+		MobileNumber string `json:"mobile_number"`
+		// CountryCode is the country code of the sub-account holder.
+		CountryCode string `json:"country_code"`
+		// Address is the address of the sub-account holder.
+		Address string `json:"address"`
+		// MarginAccess is the margin access level of the sub-account (e.g. DEFAULT, DISABLED).
+		MarginAccess string `json:"margin_access"`
+		// DerivativesAccess is the derivatives access level of the sub-account (e.g. DEFAULT, DISABLED).
+		DerivativesAccess string `json:"derivatives_access"`
+		// CreateTime is the time the sub-account was created.
+		CreateTime cdctime.Time `json:"create_time"`
+		// UpdateTime is the time the sub-account was last updated.
+		UpdateTime cdctime.Time `json:"update_time"`
+		// TwoFAEnabled is true if two-factor authentication is enabled for the sub-account.
+		TwoFAEnabled bool `json:"two_fa_enabled"`
+		// KYCLevel is the KYC level of the sub-account.
+		KYCLevel string `json:"kyc_level"`
+		// Suspended is true if the sub-account is suspended.
+		Suspended bool `json:"suspended"`
+		// Terminated is true if the sub-account is terminated.
+		Terminated bool `json:"terminated"`
+	}
+)
+
+// GetSubAccounts gets all sub-accounts of the master account.
+//
+// Method: private/subaccount/get-sub-accounts
+func (c *client) GetSubAccounts(ctx context.Context) ([]SubAccount, error) {
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetSubAccounts,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetSubAccounts,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var subAccountsResponse GetSubAccountsResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetSubAccounts, &subAccountsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, subAccountsResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	return subAccountsResponse.Result.SubAccountList, nil
+}
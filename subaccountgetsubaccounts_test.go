@@ -0,0 +1,216 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_GetSubAccounts_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetSubAccounts,
+				Timestamp: now.UnixMilli(),
+				Params:    map[string]interface{}{},
+			}).Return("signature", tt.signatureErr)
+
+			subAccounts, err := client.GetSubAccounts(ctx)
+			require.Error(t, err)
+
+			assert.Empty(t, subAccounts)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetSubAccounts_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetSubAccounts)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetSubAccounts, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{
+			"id": 0,
+			"method": "",
+			"code": 0,
+			"result": {
+				"sub_account_list": [
+					{
+						"uuid": "some sub account uuid",
+						"master_account_uuid": "some master account uuid",
+						"label": "some label",
+						"enabled": true,
+						"tradable": true,
+						"email": "some email",
+						"margin_access": "DEFAULT",
+						"derivatives_access": "DISABLED",
+						"create_time": %d,
+						"update_time": %d
+					}
+				]
+			}
+		}`, now.UnixMilli(), now.UnixMilli())))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetSubAccounts,
+		Timestamp: now.UnixMilli(),
+		Params:    map[string]interface{}{},
+	}).Return(signature, nil)
+
+	subAccounts, err := client.GetSubAccounts(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []cdcexchange.SubAccount{
+		{
+			UUID:              "some sub account uuid",
+			MasterAccountUUID: "some master account uuid",
+			Label:             "some label",
+			Enabled:           true,
+			Tradable:          true,
+			Email:             "some email",
+			MarginAccess:      "DEFAULT",
+			DerivativesAccess: "DISABLED",
+			CreateTime:        cdctime.Time(now),
+			UpdateTime:        cdctime.Time(now),
+		},
+	}, subAccounts)
+}
@@ -0,0 +1,164 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodGetSubAccountTransferHistory = "private/subaccount/get-transfer-history"
+)
+
+type (
+	// GetSubAccountTransferHistoryRequest is the request params sent for the private/subaccount/get-transfer-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	GetSubAccountTransferHistoryRequest struct {
+		// SubAccountUUID is the UUID of the sub-account to get transfers for.
+		// if SubAccountUUID is omitted, transfers of the master account will be returned.
+		SubAccountUUID string `json:"sub_account_uuid"`
+		// Direction is the direction of the transfers relative to the account (IN/OUT).
+		// if Direction is omitted, transfers in both directions will be returned.
+		Direction TransferDirection `json:"direction"`
+		// Currency is the currency of the transfers (e.g. BTC).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of transfers returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetSubAccountTransferHistoryResponse is the base response returned from the private/subaccount/get-transfer-history API.
+	GetSubAccountTransferHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetSubAccountTransferHistoryResult `json:"result"`
+	}
+
+	// GetSubAccountTransferHistoryResult is the result returned from the private/subaccount/get-transfer-history API.
+	GetSubAccountTransferHistoryResult struct {
+		// TransferList is the array of transfers.
+		TransferList []SubAccountTransferRecord `json:"transfer_list"`
+		// HasMore reports whether there may be more transfers after this page.
+		// The Exchange does not return a total count, so HasMore is true whenever the page is full,
+		// and may be true when no further transfers exist.
+		HasMore bool `json:"-"`
+		// NextPage is the page number of the next page, set only if HasMore is true.
+		NextPage int `json:"-"`
+	}
+
+	// SubAccountTransferRecord represents the details of a transfer between the master account and a sub-account.
+	SubAccountTransferRecord struct {
+		// Direction is the direction of the transfer relative to the account (IN/OUT).
+		Direction TransferDirection `json:"direction"`
+		// Time is the time of the transfer.
+		Time cdctime.Time `json:"time"`
+		// Amount is the amount transferred.
+		Amount decimal.Decimal `json:"amount"`
+		// Status is the status of the transfer (e.g. COMPLETED).
+		Status string `json:"status"`
+		// Information is a description of the transfer (e.g. From Master Account).
+		Information string `json:"information"`
+		// Currency is the currency transferred (e.g. USDT).
+		Currency string `json:"currency"`
+	}
+)
+
+// GetSubAccountTransferHistory gets the history of transfers between the master account and sub-accounts.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, request the NextPage of each result (starting with 0) while HasMore is true.
+// HasMore may be true when no further results exist, in which case the next page is empty.
+//
+// Method: private/subaccount/get-transfer-history
+func (c *client) GetSubAccountTransferHistory(ctx context.Context, req GetSubAccountTransferHistoryRequest) (*GetSubAccountTransferHistoryResult, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	if req.SubAccountUUID != "" {
+		params["sub_account_uuid"] = req.SubAccountUUID
+	}
+	if req.Direction != "" {
+		params["direction"] = req.Direction
+	}
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodGetSubAccountTransferHistory,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodGetSubAccountTransferHistory,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var transferHistoryResponse GetSubAccountTransferHistoryResponse
+	statusCode, err := c.requester.Post(ctx, body, methodGetSubAccountTransferHistory, &transferHistoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, transferHistoryResponse.Code); err != nil {
+		return nil, fmt.Errorf("error received in response: %w", err)
+	}
+
+	res := transferHistoryResponse.Result
+	if len(res.TransferList) == pageSize(req.PageSize) {
+		res.HasMore, res.NextPage = true, req.Page+1
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,98 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+)
+
+const (
+	methodSubAccountTransfer = "private/subaccount/transfer"
+)
+
+type (
+	// SubAccountTransferRequest is the request params sent for the private/subaccount/transfer API.
+	SubAccountTransferRequest struct {
+		// Currency is the currency to transfer (e.g. USDT).
+		Currency string `json:"currency"`
+		// From is the UUID of the account to be debited (master or sub-account).
+		From string `json:"from"`
+		// To is the UUID of the account to be credited (master or sub-account).
+		To string `json:"to"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// SubAccountTransferResponse is the base response returned from the private/subaccount/transfer API.
+	SubAccountTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// SubAccountTransfer transfers funds between the master account and a sub-account, or between sub-accounts.
+//
+// Method: private/subaccount/transfer
+func (c *client) SubAccountTransfer(ctx context.Context, req SubAccountTransferRequest) error {
+	switch {
+	case req.Currency == "":
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	case req.From == "":
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "cannot be empty"}
+	case req.To == "":
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be empty"}
+	case req.From == req.To:
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be the same as req.From"}
+	case req.Amount.Sign() <= 0:
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+		params    = make(map[string]interface{})
+	)
+
+	params["currency"] = req.Currency
+	params["from"] = req.From
+	params["to"] = req.To
+	params["amount"] = req.Amount
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodSubAccountTransfer,
+		Timestamp: timestamp,
+		Params:    params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodSubAccountTransfer,
+		Nonce:     timestamp,
+		Params:    params,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	var transferResponse SubAccountTransferResponse
+	statusCode, err := c.requester.WithoutRetries().Post(ctx, body, methodSubAccountTransfer, &transferResponse)
+	if err != nil {
+		return fmt.Errorf("failed to execute post request: %w", err)
+	}
+
+	if err := c.requester.CheckErrorResponse(statusCode, transferResponse.Code); err != nil {
+		return fmt.Errorf("error received in response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/id"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/websocket"
+)
+
+const (
+	methodAuth = "public/auth"
+
+	channelUserBalance = "user.balance"
+)
+
+// userWebsocket is a concrete implementation of UserWebsocket.
+type userWebsocket struct {
+	conn        *websocket.Conn
+	events      *websocketEvents
+	idGenerator id.IDGenerator
+	// nonce returns the nonce of a request, adjusted to the Exchange's clock.
+	nonce func() int64
+
+	// mu guards cancelOnDisconnect, the scope re-applied each time the session is re-established.
+	mu                 sync.Mutex
+	cancelOnDisconnect CancelOnDisconnectScope
+}
+
+// NewUserWebsocket opens a new connection to the user websocket, authenticating with the client's API key.
+// The connection is re-authenticated each time it is re-established.
+//
+// The connection should be closed once it is no longer needed.
+//
+// Method: public/auth
+func (c *client) NewUserWebsocket(ctx context.Context) (UserWebsocket, error) {
+	u := &userWebsocket{
+		events:      newWebsocketEvents(),
+		idGenerator: c.idGenerator,
+		nonce:       c.nonce,
+	}
+
+	conn, err := c.dialWebsocket(ctx, c.userWebsocketURL, u.events, func(ctx context.Context, conn *websocket.Conn) error {
+		if err := c.authenticate(ctx, conn); err != nil {
+			return err
+		}
+		return u.restoreCancelOnDisconnect(ctx, conn)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user websocket: %w", err)
+	}
+	u.conn = conn
+
+	return u, nil
+}
+
+// authenticate authenticates the websocket connection with the client's API key.
+//
+// Method: public/auth
+func (c *client) authenticate(ctx context.Context, conn *websocket.Conn) error {
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+	)
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodAuth,
+		Timestamp: timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	body := api.Request{
+		ID:        id,
+		Method:    methodAuth,
+		Nonce:     timestamp,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}
+
+	if _, err := conn.Send(ctx, body); err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeOrders subscribes to updates of the user's orders for a particular instrument.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: user.order.{instrument_name}
+func (u *userWebsocket) SubscribeOrders(ctx context.Context, instrument string) (<-chan Order, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	return subscribe[Order](ctx, u.conn, userOrderChannel(instrument))
+}
+
+// UnsubscribeOrders cancels a subscription to order updates.
+//
+// Channel: user.order.{instrument_name}
+func (u *userWebsocket) UnsubscribeOrders(ctx context.Context, instrument string) error {
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	if err := u.conn.Unsubscribe(ctx, userOrderChannel(instrument)); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeTrades subscribes to trades executed by the user for a particular instrument.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: user.trade.{instrument_name}
+func (u *userWebsocket) SubscribeTrades(ctx context.Context, instrument string) (<-chan Trade, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	return subscribe[Trade](ctx, u.conn, userTradeChannel(instrument))
+}
+
+// UnsubscribeTrades cancels a subscription to the user's executed trades.
+//
+// Channel: user.trade.{instrument_name}
+func (u *userWebsocket) UnsubscribeTrades(ctx context.Context, instrument string) error {
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	if err := u.conn.Unsubscribe(ctx, userTradeChannel(instrument)); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeBalance subscribes to updates of the user's balances.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+//
+// Channel: user.balance
+func (u *userWebsocket) SubscribeBalance(ctx context.Context) (<-chan Account, error) {
+	return subscribe[Account](ctx, u.conn, channelUserBalance)
+}
+
+// UnsubscribeBalance cancels a subscription to balance updates.
+//
+// Channel: user.balance
+func (u *userWebsocket) UnsubscribeBalance(ctx context.Context) error {
+	if err := u.conn.Unsubscribe(ctx, channelUserBalance); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// Events returns a channel on which connection events are delivered (e.g. reconnecting, a missed heartbeat).
+//
+// The channel is closed once the connection is closed.
+func (u *userWebsocket) Events() <-chan WebsocketEvent {
+	return u.events.ch
+}
+
+// Close closes the connection, along with all of its subscriptions.
+func (u *userWebsocket) Close() error {
+	return u.conn.Close()
+}
+
+func userOrderChannel(instrument string) string {
+	return fmt.Sprintf("user.order.%s", instrument)
+}
+
+func userTradeChannel(instrument string) string {
+	return fmt.Sprintf("user.trade.%s", instrument)
+}
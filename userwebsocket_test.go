@@ -0,0 +1,413 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/auth"
+	id_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/id"
+	signature_mocks "github.com/cshep4/crypto-dot-com-exchange-go/internal/mocks/signature"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+func TestClient_NewUserWebsocket_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		signatureErr error
+		responseCode int64
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name:         "returns error given error response",
+			responseCode: 10002,
+			expectedErr:  cdcerrors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				req, ok := readRequest(conn)
+				if !ok {
+					return
+				}
+
+				writeResponse(t, conn, websocketResponse{
+					ID:     req.ID,
+					Method: req.Method,
+					Code:   tt.responseCode,
+				})
+				waitForClose(conn)
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+				cdcexchange.WithUserWebsocketURL(url),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodAuth,
+				Timestamp: now.UnixMilli(),
+			}).Return("signature", tt.signatureErr)
+
+			ws, err := client.NewUserWebsocket(ctx)
+			require.Error(t, err)
+
+			assert.Nil(t, ws)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+		})
+	}
+}
+
+func TestClient_NewUserWebsocket_Success(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		id         = int64(1234)
+		signature  = "some signature"
+		instrument = "some instrument"
+	)
+	var (
+		now      = time.Now().Round(time.Second)
+		received = make(chan struct{})
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+
+		assert.Equal(t, cdcexchange.MethodAuth, req.Method)
+		assert.Equal(t, id, req.ID)
+		assert.Equal(t, apiKey, req.APIKey)
+		assert.Equal(t, signature, req.Signature)
+		assert.Equal(t, now.UnixMilli(), req.Nonce)
+
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+		subscriptions := map[string]interface{}{
+			"user.order.some instrument": []map[string]interface{}{
+				{
+					"status":          "ACTIVE",
+					"side":            "BUY",
+					"price":           1.1,
+					"quantity":        2.2,
+					"order_id":        "some order id",
+					"create_time":     now.UnixMilli(),
+					"update_time":     now.UnixMilli(),
+					"type":            "LIMIT",
+					"instrument_name": instrument,
+				},
+			},
+			"user.trade.some instrument": []map[string]interface{}{
+				{
+					"side":            "SELL",
+					"instrument_name": instrument,
+					"fee":             0.1,
+					"trade_id":        "some trade id",
+					"create_time":     now.UnixMilli(),
+					"traded_price":    1.1,
+					"traded_quantity": 2.2,
+					"order_id":        "some order id",
+				},
+			},
+			"user.balance": []map[string]interface{}{
+				{
+					"currency":  "CRO",
+					"balance":   3.3,
+					"available": 2.2,
+					"order":     1.1,
+				},
+			},
+		}
+
+		for range subscriptions {
+			req, ok := readRequest(conn)
+			if !assert.True(t, ok) {
+				return
+			}
+
+			channel := req.Params["channels"].([]interface{})[0].(string)
+			data, ok := subscriptions[channel]
+			if !assert.True(t, ok) {
+				return
+			}
+
+			writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+			writeResponse(t, conn, websocketResponse{
+				ID:     -1,
+				Method: "subscribe",
+				Result: subscriptionResult{
+					Subscription: channel,
+					Data:         data,
+				},
+			})
+		}
+
+		<-received
+	})
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithUserWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnectDisabled(),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodAuth,
+		Timestamp: now.UnixMilli(),
+	}).Return(signature, nil)
+
+	ws, err := client.NewUserWebsocket(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	orders, err := ws.SubscribeOrders(ctx, instrument)
+	require.NoError(t, err)
+
+	select {
+	case order := <-orders:
+		assert.Equal(t, cdcexchange.Order{
+			Status:         cdcexchange.OrderStatusActive,
+			Side:           cdcexchange.OrderSideBuy,
+			Price:          decimal.MustParse("1.1"),
+			Quantity:       decimal.MustParse("2.2"),
+			OrderID:        "some order id",
+			CreateTime:     cdctime.Time(now),
+			UpdateTime:     cdctime.Time(now),
+			OrderType:      cdcexchange.OrderTypeLimit,
+			InstrumentName: instrument,
+		}, order)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order")
+	}
+
+	trades, err := ws.SubscribeTrades(ctx, instrument)
+	require.NoError(t, err)
+
+	select {
+	case trade := <-trades:
+		assert.Equal(t, cdcexchange.Trade{
+			Side:           cdcexchange.OrderSideSell,
+			InstrumentName: instrument,
+			Fee:            decimal.MustParse("0.1"),
+			TradeID:        "some trade id",
+			CreateTime:     cdctime.Time(now),
+			TradedPrice:    decimal.MustParse("1.1"),
+			TradedQuantity: decimal.MustParse("2.2"),
+			OrderID:        "some order id",
+		}, trade)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for trade")
+	}
+
+	balances, err := ws.SubscribeBalance(ctx)
+	require.NoError(t, err)
+
+	select {
+	case balance := <-balances:
+		assert.Equal(t, cdcexchange.Account{
+			Currency:  "CRO",
+			Balance:   decimal.MustParse("3.3"),
+			Available: decimal.MustParse("2.2"),
+			Order:     decimal.MustParse("1.1"),
+		}, balance)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for balance")
+	}
+	close(received)
+
+	assertClosed(t, orders)
+	assertClosed(t, trades)
+	assertClosed(t, balances)
+}
+
+func TestUserWebsocket_Unsubscribe_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		unsubscribe func(ctx context.Context, ws cdcexchange.UserWebsocket) error
+	}{
+		{
+			name: "returns error when orders instrument is empty",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.UserWebsocket) error {
+				return ws.UnsubscribeOrders(ctx, "")
+			},
+		},
+		{
+			name: "returns error when trades instrument is empty",
+			unsubscribe: func(ctx context.Context, ws cdcexchange.UserWebsocket) error {
+				return ws.UnsubscribeTrades(ctx, "")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				if !acceptAuth(t, conn) {
+					return
+				}
+
+				// no request is sent given invalid parameters.
+				if req, ok := readRequest(conn); ok {
+					t.Errorf("unexpected request: %s", req.Method)
+				}
+			})
+
+			ws := newCancelOnDisconnectWebsocket(t, ctx, url, clockwork.NewFakeClock())
+
+			err := tt.unsubscribe(ctx, ws)
+			require.Error(t, err)
+
+			assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}, err)
+		})
+	}
+}
+
+func TestUserWebsocket_Reconnect(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		id         = int64(1234)
+		signature  = "some signature"
+		maxBackoff = 10 * time.Second
+	)
+
+	var connections int32
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		// the connection is authenticated each time it is established.
+		req, ok := readRequest(conn)
+		if !ok {
+			return
+		}
+		assert.Equal(t, cdcexchange.MethodAuth, req.Method)
+		assert.Equal(t, signature, req.Signature)
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+		req, ok = readRequest(conn)
+		if !ok {
+			return
+		}
+		assert.Equal(t, "subscribe", req.Method)
+		assert.Equal(t, []interface{}{"user.balance"}, req.Params["channels"])
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			// drop the first connection once subscribed.
+			return
+		}
+
+		waitForClose(conn)
+	})
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		now                = time.Now()
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithUserWebsocketURL(url),
+		cdcexchange.WithWebsocketHeartbeatTimeout(0),
+		cdcexchange.WithWebsocketReconnectBackoff(time.Second, maxBackoff),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodAuth,
+		Timestamp: now.UnixMilli(),
+	}).Return(signature, nil)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodAuth,
+		Timestamp: now.Add(maxBackoff).UnixMilli(),
+	}).Return(signature, nil)
+
+	ws, err := client.NewUserWebsocket(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+
+	_, err = ws.SubscribeBalance(ctx)
+	require.NoError(t, err)
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventReconnecting)
+
+	clock.BlockUntil(1)
+	clock.Advance(maxBackoff)
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventResubscribed)
+}
@@ -0,0 +1,161 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/websocket"
+)
+
+const (
+	defaultWebsocketHeartbeatTimeout    = time.Minute
+	defaultWebsocketReconnectMinBackoff = 500 * time.Millisecond
+	defaultWebsocketReconnectMaxBackoff = 30 * time.Second
+	websocketEventBufferSize            = 100
+
+	// WebsocketEventConnected is emitted once a connection is established (and authenticated for user connections).
+	WebsocketEventConnected WebsocketEventType = "CONNECTED"
+	// WebsocketEventReconnecting is emitted before each attempt to re-establish a lost connection.
+	WebsocketEventReconnecting WebsocketEventType = "RECONNECTING"
+	// WebsocketEventResubscribed is emitted once all active subscriptions are replayed on a re-established connection.
+	WebsocketEventResubscribed WebsocketEventType = "RESUBSCRIBED"
+	// WebsocketEventDisconnected is emitted once a connection is closed and will not be re-established.
+	WebsocketEventDisconnected WebsocketEventType = "DISCONNECTED"
+	// WebsocketEventHeartbeatMissed is emitted if a heartbeat is not received within the heartbeat timeout.
+	WebsocketEventHeartbeatMissed WebsocketEventType = "HEARTBEAT_MISSED"
+	// WebsocketEventMessageDropped is emitted if a message is dropped because a subscription is not consumed fast enough.
+	WebsocketEventMessageDropped WebsocketEventType = "MESSAGE_DROPPED"
+)
+
+type (
+	// WebsocketEventType is the type of event emitted for a websocket connection.
+	WebsocketEventType string
+
+	// WebsocketEvent is emitted when the state of a websocket connection changes.
+	WebsocketEvent struct {
+		// Type is the type of event.
+		Type WebsocketEventType
+		// Err is the error which caused the event (if any).
+		Err error
+	}
+
+	// websocketReconnectBackoff bounds the delay between attempts to re-establish a lost connection,
+	// connections are not re-established if max is 0.
+	websocketReconnectBackoff struct {
+		min time.Duration
+		max time.Duration
+	}
+
+	// websocketEvents delivers the events emitted for a websocket connection.
+	websocketEvents struct {
+		mu     sync.Mutex
+		ch     chan WebsocketEvent
+		closed bool
+	}
+)
+
+// dialWebsocket establishes a new websocket connection, events for the connection are emitted to events.
+// If authenticate is not nil it is called each time the connection is established.
+func (c *client) dialWebsocket(
+	ctx context.Context,
+	url string,
+	events *websocketEvents,
+	authenticate func(context.Context, *websocket.Conn) error,
+) (*websocket.Conn, error) {
+	conn, err := websocket.Dial(ctx, websocket.Config{
+		URL:                 url,
+		IDGenerator:         c.idGenerator,
+		Clock:               c.clock,
+		HeartbeatTimeout:    c.websocketHeartbeatTimeout,
+		ReconnectMinBackoff: c.websocketReconnectBackoff.min,
+		ReconnectMaxBackoff: c.websocketReconnectBackoff.max,
+		Authenticate:        authenticate,
+		OnEvent:             events.emit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-conn.Done()
+		events.close()
+	}()
+
+	return conn, nil
+}
+
+func newWebsocketEvents() *websocketEvents {
+	return &websocketEvents{
+		ch: make(chan WebsocketEvent, websocketEventBufferSize),
+	}
+}
+
+// emit delivers the event, it is dropped if the buffer is full so the connection is never blocked.
+func (e *websocketEvents) emit(event websocket.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return
+	}
+
+	select {
+	case e.ch <- WebsocketEvent{Type: WebsocketEventType(event.Type), Err: event.Err}:
+	default:
+	}
+}
+
+func (e *websocketEvents) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.closed {
+		e.closed = true
+		close(e.ch)
+	}
+}
+
+// subscribe subscribes to channel on conn, the data of each message pushed on the subscription is decoded as a list of T
+// and each element is delivered on the returned channel.
+//
+// The returned channel is closed once the subscription is cancelled or the connection is closed.
+func subscribe[T any](ctx context.Context, conn *websocket.Conn, channel string) (<-chan T, error) {
+	sub, err := conn.Subscribe(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	results := make(chan T)
+
+	go func() {
+		defer close(results)
+
+		for {
+			var msg websocket.SubscriptionResult
+			select {
+			case msg = <-sub.Messages():
+			case <-sub.Done():
+				return
+			}
+
+			var data []T
+			// messages which cannot be decoded are skipped rather than closing the subscription.
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				continue
+			}
+
+			for _, res := range data {
+				select {
+				case results <- res:
+				case <-sub.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,349 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/cshep4/crypto-dot-com-exchange-go"
+	"github.com/cshep4/crypto-dot-com-exchange-go/decimal"
+	cdcerrors "github.com/cshep4/crypto-dot-com-exchange-go/errors"
+	"github.com/cshep4/crypto-dot-com-exchange-go/internal/api"
+	cdctime "github.com/cshep4/crypto-dot-com-exchange-go/internal/time"
+)
+
+type (
+	websocketResponse struct {
+		ID     int64       `json:"id"`
+		Method string      `json:"method"`
+		Code   int64       `json:"code"`
+		Result interface{} `json:"result,omitempty"`
+	}
+
+	subscriptionResult struct {
+		InstrumentName string      `json:"instrument_name,omitempty"`
+		Subscription   string      `json:"subscription"`
+		Channel        string      `json:"channel"`
+		Data           interface{} `json:"data"`
+	}
+)
+
+// newWebsocketServer starts a websocket server which passes each new connection to handler,
+// the returned URL can be used to connect to the server.
+func newWebsocketServer(t *testing.T, handler func(conn *websocket.Conn)) string {
+	upgrader := websocket.Upgrader{}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		handler(conn)
+	}))
+	t.Cleanup(s.Close)
+
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// readRequest reads the next request sent by the client, false is returned if the connection is closed.
+func readRequest(conn *websocket.Conn) (api.Request, bool) {
+	var req api.Request
+	if err := conn.ReadJSON(&req); err != nil {
+		return api.Request{}, false
+	}
+
+	return req, true
+}
+
+func writeResponse(t *testing.T, conn *websocket.Conn, res interface{}) {
+	b, err := json.Marshal(res)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, b))
+}
+
+// waitForClose blocks until the connection is closed by the client.
+func waitForClose(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// drain receives from the channel until it is closed.
+func drain(ch interface{}) {
+	v := reflect.ValueOf(ch)
+	for {
+		if _, ok := v.Recv(); !ok {
+			return
+		}
+	}
+}
+
+func TestWebsocket_RespondsToHeartbeats(t *testing.T) {
+	const heartbeatID = 1234
+
+	responses := make(chan api.Request, 1)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		writeResponse(t, conn, websocketResponse{
+			ID:     heartbeatID,
+			Method: "public/heartbeat",
+		})
+
+		req, ok := readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		responses <- req
+
+		waitForClose(conn)
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	select {
+	case res := <-responses:
+		assert.Equal(t, int64(heartbeatID), res.ID)
+		assert.Equal(t, "public/respond-heartbeat", res.Method)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for heartbeat response")
+	}
+}
+
+func TestWebsocket_HeartbeatMissed(t *testing.T) {
+	const timeout = 30 * time.Second
+
+	url := newWebsocketServer(t, waitForClose)
+
+	clock := clockwork.NewFakeClock()
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketHeartbeatTimeout(timeout),
+		cdcexchange.WithWebsocketReconnectDisabled(),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+
+	clock.BlockUntil(1)
+	clock.Advance(timeout)
+
+	event := assertEvent(t, ws.Events(), cdcexchange.WebsocketEventHeartbeatMissed)
+	assert.True(t, errors.Is(event.Err, cdcerrors.ErrHeartbeatMissed))
+
+	// the stale connection is closed as it is not re-established.
+	event = assertEvent(t, ws.Events(), cdcexchange.WebsocketEventDisconnected)
+	assert.True(t, errors.Is(event.Err, cdcerrors.ErrHeartbeatMissed))
+
+	assertClosed(t, ws.Events())
+}
+
+func TestWebsocket_Reconnect(t *testing.T) {
+	const (
+		instrument = "some instrument"
+		channel    = "ticker.some instrument"
+		maxBackoff = 10 * time.Second
+	)
+	now := time.Now().Round(time.Second)
+
+	var connections int32
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !ok {
+			return
+		}
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			assert.Equal(t, []interface{}{channel}, req.Params["channels"])
+			writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+			// drop the first connection once subscribed.
+			return
+		}
+
+		// the subscription is replayed on the new connection.
+		assert.Equal(t, "subscribe", req.Method)
+		assert.Equal(t, []interface{}{channel}, req.Params["channels"])
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+		writeResponse(t, conn, websocketResponse{
+			ID:     -1,
+			Method: "subscribe",
+			Result: subscriptionResult{
+				Subscription: channel,
+				Data: []map[string]interface{}{
+					{"i": instrument, "b": 1.1, "t": now.UnixMilli()},
+				},
+			},
+		})
+
+		waitForClose(conn)
+	})
+
+	clock := clockwork.NewFakeClock()
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketHeartbeatTimeout(0),
+		cdcexchange.WithWebsocketReconnectBackoff(time.Second, maxBackoff),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+
+	tickers, err := ws.SubscribeTicker(context.Background(), instrument)
+	require.NoError(t, err)
+
+	event := assertEvent(t, ws.Events(), cdcexchange.WebsocketEventReconnecting)
+	assert.True(t, errors.Is(event.Err, cdcerrors.ErrConnectionClosed))
+
+	clock.BlockUntil(1)
+	clock.Advance(maxBackoff)
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventResubscribed)
+
+	// messages continue to be delivered on the original subscription.
+	select {
+	case ticker := <-tickers:
+		assert.Equal(t, cdcexchange.Ticker{
+			Instrument: instrument,
+			BidPrice:   decimal.MustParse("1.1"),
+			Timestamp:  cdctime.Time(now),
+		}, ticker)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker")
+	}
+
+	require.NoError(t, ws.Close())
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventDisconnected)
+	assertClosed(t, tickers)
+	assertClosed(t, ws.Events())
+}
+
+func TestWebsocket_SlowSubscriber(t *testing.T) {
+	const (
+		instrument  = "some instrument"
+		channel     = "ticker.some instrument"
+		messages    = 150
+		heartbeatID = 1234
+	)
+
+	responses := make(chan api.Request, 1)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req, ok := readRequest(conn)
+		if !ok {
+			return
+		}
+		writeResponse(t, conn, websocketResponse{ID: req.ID, Method: req.Method})
+
+		// more messages are pushed than the subscription can buffer, as none of them are consumed.
+		for i := 0; i < messages; i++ {
+			writeResponse(t, conn, websocketResponse{
+				ID:     -1,
+				Method: "subscribe",
+				Result: subscriptionResult{
+					Subscription: channel,
+					Data:         []map[string]interface{}{{"i": instrument}},
+				},
+			})
+		}
+		writeResponse(t, conn, websocketResponse{ID: heartbeatID, Method: "public/heartbeat"})
+
+		req, ok = readRequest(conn)
+		if !assert.True(t, ok) {
+			return
+		}
+		responses <- req
+
+		waitForClose(conn)
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnectDisabled(),
+	)
+	require.NoError(t, err)
+
+	ws, err := client.NewMarketWebsocket(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	assertEvent(t, ws.Events(), cdcexchange.WebsocketEventConnected)
+
+	tickers, err := ws.SubscribeTicker(context.Background(), instrument)
+	require.NoError(t, err)
+
+	// the heartbeat is still responded to while the subscription is not consumed.
+	select {
+	case res := <-responses:
+		assert.Equal(t, int64(heartbeatID), res.ID)
+		assert.Equal(t, "public/respond-heartbeat", res.Method)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for heartbeat response")
+	}
+
+	// the event is emitted once, not for each message dropped.
+	event := assertEvent(t, ws.Events(), cdcexchange.WebsocketEventMessageDropped)
+	assert.True(t, errors.Is(event.Err, cdcerrors.ErrMessageDropped))
+	assert.Empty(t, ws.Events())
+
+	// the buffered messages are still delivered.
+	select {
+	case ticker := <-tickers:
+		assert.Equal(t, instrument, ticker.Instrument)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker")
+	}
+}
+
+// assertEvent asserts the next event received is of the expected type.
+func assertEvent(t *testing.T, events <-chan cdcexchange.WebsocketEvent, expected cdcexchange.WebsocketEventType) cdcexchange.WebsocketEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		assert.Equal(t, expected, event.Type)
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for %s event", expected)
+		return cdcexchange.WebsocketEvent{}
+	}
+}